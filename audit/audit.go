@@ -0,0 +1,314 @@
+// Package audit records every change to habits and check-ins as an
+// AuditEvent, using a global ent hook.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	gen "api/ent"
+	"api/ent/auditevent"
+	"api/ent/completion"
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"entgo.io/ent"
+)
+
+// Entity types as named by the API.
+const (
+	EntityHabit   = "habit"
+	EntityCheckin = "checkin"
+)
+
+// ErrUndone is returned for a change made with WithUndo when the event was
+// undone already. The change is rolled back with its event.
+var ErrUndone = errors.New("audit: change was already undone")
+
+type (
+	actorKey    struct{}
+	undoKey     struct{}
+	eventIDsKey struct{}
+)
+
+// auditingKey marks mutations made while recording another one, such as
+// the update a soft delete turns into, so they are not recorded twice.
+type auditingKey struct{}
+
+// WithActor returns a context whose changes are attributed to the user.
+func WithActor(parent context.Context, userID int) context.Context {
+	return context.WithValue(parent, actorKey{}, userID)
+}
+
+// WithUndo returns a context whose changes are recorded as reverting the
+// given event.
+func WithUndo(parent context.Context, eventID int) context.Context {
+	return context.WithValue(parent, undoKey{}, eventID)
+}
+
+// WithEventIDs returns a context whose changes append the IDs of their
+// events to ids, for instance to tell the client which event to undo.
+func WithEventIDs(parent context.Context, ids *[]int) context.Context {
+	return context.WithValue(parent, eventIDsKey{}, ids)
+}
+
+func actor(ctx context.Context) *int {
+	if id, ok := ctx.Value(actorKey{}).(int); ok {
+		return &id
+	}
+	return nil
+}
+
+func undoOf(ctx context.Context) *int {
+	if id, ok := ctx.Value(undoKey{}).(int); ok {
+		return &id
+	}
+	return nil
+}
+
+// snapshot is the state of an entity as served by the API.
+type snapshot struct {
+	owner  int
+	fields map[string]any
+}
+
+// audited describes how to load the entities changed by a mutation.
+type audited struct {
+	entity string
+	client *gen.Client
+	load   func(ctx context.Context, ids []int) (map[int]snapshot, error)
+}
+
+// lookup returns how to audit m, or false for entities that are not
+// audited.
+func lookup(m ent.Mutation) (audited, bool) {
+	switch m := m.(type) {
+	case *gen.HabitMutation:
+		client := m.Client()
+		return audited{EntityHabit, client, func(ctx context.Context, ids []int) (map[int]snapshot, error) {
+			habits, err := client.Habit.Query().Where(habit.IDIn(ids...)).All(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out := make(map[int]snapshot, len(habits))
+			for _, h := range habits {
+				out[h.ID] = snapshot{owner: h.OwnerID, fields: fieldsOf(h)}
+			}
+			return out, nil
+		}}, true
+	case *gen.CompletionMutation:
+		client := m.Client()
+		return audited{EntityCheckin, client, func(ctx context.Context, ids []int) (map[int]snapshot, error) {
+			completions, err := client.Completion.Query().
+				Where(completion.IDIn(ids...)).
+				WithHabit(func(q *gen.HabitQuery) { q.Select(habit.FieldOwnerID) }).
+				All(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out := make(map[int]snapshot, len(completions))
+			for _, c := range completions {
+				out[c.ID] = snapshot{owner: c.Edges.Habit.OwnerID, fields: fieldsOf(c)}
+			}
+			return out, nil
+		}}, true
+	}
+	return audited{}, false
+}
+
+// Hook records the creates, updates and deletes of habits and check-ins,
+// with the values of the changed fields before and after. A change made
+// outside a transaction is recorded in one the hook opens, so that it is
+// never saved without its event; that requires the driver of the client to
+// be wrapped with Driver. Register it with Client.Use.
+func Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			a, ok := lookup(m)
+			if !ok || ctx.Value(auditingKey{}) != nil {
+				return next.Mutate(ctx, m)
+			}
+			ctx = context.WithValue(ctx, auditingKey{}, true)
+			if _, err := m.(interface{ Tx() (*gen.Tx, error) }).Tx(); err == nil {
+				return record(ctx, a, m, next)
+			}
+			return recordInTx(ctx, a, m, next)
+		})
+	}
+}
+
+// recordInTx opens a transaction that the statements of m and of its event
+// run in, through the context, and records m in it.
+func recordInTx(ctx context.Context, a audited, m ent.Mutation, next ent.Mutator) (v ent.Value, err error) {
+	slot := &txSlot{}
+	ctx = context.WithValue(ctx, txKey{}, slot)
+	tx, err := a.client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("audit: %w", err)
+	}
+	if slot.tx == nil {
+		tx.Rollback()
+		return nil, errors.New("audit: the driver of the client is not wrapped with audit.Driver")
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	if v, err = record(ctx, a, m, next); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("audit: committing: %w", err)
+	}
+	return v, nil
+}
+
+// record applies m and saves an event for each entity it changed.
+func record(ctx context.Context, a audited, m ent.Mutation, next ent.Mutator) (ent.Value, error) {
+	// Trashed habits are audited like any other
+	loadCtx := schema.SkipSoftDelete(ctx)
+	// Taken now, as soft deletes turn the mutation into an update
+	op := m.Op()
+
+	var ids []int
+	before := map[int]snapshot{}
+	if !op.Is(ent.OpCreate) {
+		var err error
+		if ids, err = mutationIDs(loadCtx, m); err != nil {
+			return nil, fmt.Errorf("audit: %w", err)
+		}
+		if len(ids) > 0 {
+			if before, err = a.load(loadCtx, ids); err != nil {
+				return nil, fmt.Errorf("audit: %w", err)
+			}
+		}
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return v, err
+	}
+	if op.Is(ent.OpCreate) {
+		if id, ok := m.(interface{ ID() (int, bool) }).ID(); ok {
+			ids = []int{id}
+		}
+	}
+	if len(ids) == 0 {
+		return v, nil
+	}
+	after, err := a.load(loadCtx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("audit: %w", err)
+	}
+
+	var events []*gen.AuditEventCreate
+	for _, id := range ids {
+		b, hadBefore := before[id]
+		s, hasAfter := after[id]
+		if !hadBefore && !hasAfter {
+			continue
+		}
+		owner := s.owner
+		if !hasAfter {
+			owner = b.owner
+		}
+		changedBefore, changedAfter := diff(b.fields, s.fields)
+		if len(changedBefore) == 0 && len(changedAfter) == 0 {
+			continue
+		}
+		create := a.client.AuditEvent.Create().
+			SetNillableActorID(actor(ctx)).
+			SetNillableUndoOf(undoOf(ctx)).
+			SetOwnerID(owner).
+			SetEntityType(a.entity).
+			SetEntityID(id).
+			SetOperation(operation(op))
+		if changedBefore != nil {
+			create.SetBefore(changedBefore)
+		}
+		if changedAfter != nil {
+			create.SetAfter(changedAfter)
+		}
+		events = append(events, create)
+	}
+	if len(events) > 0 {
+		saved, err := a.client.AuditEvent.CreateBulk(events...).Save(ctx)
+		if gen.IsConstraintError(err) && undoOf(ctx) != nil {
+			return nil, ErrUndone
+		}
+		if err != nil {
+			return nil, fmt.Errorf("audit: recording events: %w", err)
+		}
+		if out, ok := ctx.Value(eventIDsKey{}).(*[]int); ok {
+			for _, e := range saved {
+				*out = append(*out, e.ID)
+			}
+		}
+	}
+	return v, nil
+}
+
+// mutationIDs returns the IDs of the rows an update or delete applies to.
+func mutationIDs(ctx context.Context, m ent.Mutation) ([]int, error) {
+	im, ok := m.(interface {
+		IDs(context.Context) ([]int, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T", m)
+	}
+	return im.IDs(ctx)
+}
+
+func operation(op ent.Op) auditevent.Operation {
+	switch {
+	case op.Is(ent.OpCreate):
+		return auditevent.OperationCreate
+	case op.Is(ent.OpDelete | ent.OpDeleteOne):
+		return auditevent.OperationDelete
+	default:
+		return auditevent.OperationUpdate
+	}
+}
+
+// fieldsOf returns the JSON fields of an entity, without its ID and edges.
+func fieldsOf(v any) map[string]any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil
+	}
+	delete(fields, "id")
+	delete(fields, "edges")
+	return fields
+}
+
+// diff returns the fields that differ between before and after. Fields
+// missing on one side, because they were empty, are null there. Without a
+// before or after the other side is returned whole.
+func diff(before, after map[string]any) (map[string]any, map[string]any) {
+	if before == nil || after == nil {
+		return before, after
+	}
+	b, a := map[string]any{}, map[string]any{}
+	for k, v := range before {
+		if !reflect.DeepEqual(v, after[k]) {
+			b[k], a[k] = v, after[k]
+		}
+	}
+	for k, v := range after {
+		if _, ok := before[k]; !ok {
+			b[k], a[k] = nil, v
+		}
+	}
+	return b, a
+}
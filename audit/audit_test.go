@@ -0,0 +1,129 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/auditevent"
+	_ "api/ent/runtime"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openClient opens a SQLite file with the audit hook, and the driver wrapped
+// with audit.Driver when wrap is set.
+func openClient(t *testing.T, wrap bool) *ent.Client {
+	t.Helper()
+	drv, err := sql.Open(dialect.SQLite, "file:"+filepath.Join(t.TempDir(), "test.db")+"?_fk=1&_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d dialect.Driver = drv
+	if wrap {
+		d = audit.Driver(drv)
+	}
+	client := ent.NewClient(ent.Driver(d))
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	client.Use(audit.Hook())
+	return client
+}
+
+func TestHookRecordsChanges(t *testing.T) {
+	client := openClient(t, true)
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("user@example.com").SetPasswordHash("x").SaveX(ctx)
+	ctx = audit.WithActor(ctx, u.ID)
+
+	h := client.Habit.Create().SetName("Read").SetOwnerID(u.ID).SaveX(ctx)
+	var ids []int
+	client.Habit.UpdateOne(h).SetName("Write").SaveX(audit.WithEventIDs(ctx, &ids))
+	client.Habit.DeleteOne(h).ExecX(ctx)
+
+	events := client.AuditEvent.Query().Order(auditevent.ByID()).AllX(ctx)
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	for i, op := range []auditevent.Operation{auditevent.OperationCreate, auditevent.OperationUpdate, auditevent.OperationDelete} {
+		e := events[i]
+		if e.Operation != op || e.EntityType != audit.EntityHabit || e.EntityID != h.ID || e.OwnerID != u.ID || e.ActorID == nil || *e.ActorID != u.ID {
+			t.Errorf("got event %+v, want a %s of habit %d by user %d", e, op, h.ID, u.ID)
+		}
+	}
+	if len(ids) != 1 || ids[0] != events[1].ID {
+		t.Errorf("got event IDs %v, want %d", ids, events[1].ID)
+	}
+	// Only the changed fields are recorded
+	update := events[1]
+	if update.Before["name"] != "Read" || update.After["name"] != "Write" {
+		t.Errorf("got update from %v to %v, want the name from Read to Write", update.Before, update.After)
+	}
+	if _, ok := update.After["owner_id"]; ok {
+		t.Errorf("got unchanged owner_id in %v", update.After)
+	}
+	if events[0].Before != nil || events[0].After["name"] != "Read" {
+		t.Errorf("got create from %v to %v, want the habit after only", events[0].Before, events[0].After)
+	}
+}
+
+func TestHookInTransaction(t *testing.T) {
+	client := openClient(t, true)
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("user@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Habit.Create().SetName("Read").SetOwnerID(u.ID).SaveX(ctx)
+	if n := tx.AuditEvent.Query().CountX(ctx); n != 1 {
+		t.Errorf("got %d events in the transaction, want 1", n)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if n := client.AuditEvent.Query().CountX(ctx); n != 0 {
+		t.Errorf("got %d events after rolling back, want 0", n)
+	}
+}
+
+func TestHookRollsBackChangeWithEvent(t *testing.T) {
+	client := openClient(t, true)
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("user@example.com").SetPasswordHash("x").SaveX(ctx)
+	h := client.Habit.Create().SetName("Read").SetOwnerID(u.ID).SaveX(ctx)
+	created := client.AuditEvent.Query().OnlyX(ctx)
+
+	client.Habit.UpdateOne(h).SetName("Write").SaveX(audit.WithUndo(ctx, created.ID))
+	// Undoing the same event again fails, and the change is not saved
+	_, err := client.Habit.UpdateOne(h).SetName("Run").Save(audit.WithUndo(ctx, created.ID))
+	if !errors.Is(err, audit.ErrUndone) {
+		t.Fatalf("got error %v, want ErrUndone", err)
+	}
+	if name := client.Habit.GetX(ctx, h.ID).Name; name != "Write" {
+		t.Errorf("got name %q, want Write", name)
+	}
+	if n := client.AuditEvent.Query().CountX(ctx); n != 2 {
+		t.Errorf("got %d events, want 2", n)
+	}
+}
+
+func TestHookRequiresDriver(t *testing.T) {
+	client := openClient(t, false)
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("user@example.com").SetPasswordHash("x").SaveX(ctx)
+	if _, err := client.Habit.Create().SetName("Read").SetOwnerID(u.ID).Save(ctx); err == nil {
+		t.Error("created a habit without audit.Driver, want an error")
+	}
+	if n := client.Habit.Query().CountX(ctx); n != 0 {
+		t.Errorf("got %d habits, want 0", n)
+	}
+}
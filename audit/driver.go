@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+)
+
+// txKey carries the txSlot of a mutation that Hook records in its own
+// transaction.
+type txKey struct{}
+
+// txSlot holds the transaction opened for a mutation, once Driver began it.
+type txSlot struct {
+	tx dialect.Tx
+}
+
+// Driver wraps the driver of a client for Hook. The statements of a
+// mutation that the hook records in its own transaction run in that
+// transaction, whichever client issues them: the mutation itself, the
+// schema hooks and the audit event. Other statements are passed through.
+func Driver(drv dialect.Driver) dialect.Driver {
+	return &driver{Driver: drv}
+}
+
+type driver struct {
+	dialect.Driver
+}
+
+// Exec runs the statement in the transaction of ctx, if any.
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	if slot, ok := ctx.Value(txKey{}).(*txSlot); ok && slot.tx != nil {
+		return slot.tx.Exec(ctx, query, args, v)
+	}
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query runs the query in the transaction of ctx, if any.
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	if slot, ok := ctx.Value(txKey{}).(*txSlot); ok && slot.tx != nil {
+		return slot.tx.Query(ctx, query, args, v)
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// Tx begins the transaction Hook asked for in ctx, and joins it when one
+// is already running.
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	slot, ok := ctx.Value(txKey{}).(*txSlot)
+	if !ok {
+		return d.Driver.Tx(ctx)
+	}
+	if slot.tx != nil {
+		return joinedTx{slot.tx}, nil
+	}
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slot.tx = tx
+	return tx, nil
+}
+
+// joinedTx is a transaction joined by a nested Tx call, which is committed
+// or rolled back by whoever began it.
+type joinedTx struct {
+	dialect.Tx
+}
+
+func (joinedTx) Commit() error   { return nil }
+func (joinedTx) Rollback() error { return nil }
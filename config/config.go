@@ -0,0 +1,221 @@
+// Package config loads the server configuration from an optional YAML or
+// TOML file and environment variables, in that order of precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the complete server configuration.
+type Config struct {
+	// DatabaseDriver is postgres or sqlite.
+	DatabaseDriver string `yaml:"database_driver" toml:"database_driver"`
+	// DatabaseURL is the Postgres connection string, or the SQLite file
+	// (":memory:" for an in-memory database). Required.
+	DatabaseURL string `yaml:"database_url" toml:"database_url"`
+	// ListenAddr is the host:port the HTTP server binds to.
+	ListenAddr string `yaml:"listen_addr" toml:"listen_addr"`
+	// GinMode is one of debug, release or test.
+	GinMode string `yaml:"gin_mode" toml:"gin_mode"`
+	// TemplateGlob matches the HTML templates to load. Empty serves the API
+	// without the web UI.
+	TemplateGlob string `yaml:"template_glob" toml:"template_glob"`
+	// CORSOrigins lists the origins allowed to call the API from a browser,
+	// or "*" for any origin. Empty disables CORS.
+	CORSOrigins []string `yaml:"cors_origins" toml:"cors_origins"`
+	// LogLevel is one of debug, info, warn or error.
+	LogLevel string `yaml:"log_level" toml:"log_level"`
+	// JWTSecret signs access tokens. Required in release mode.
+	JWTSecret string `yaml:"jwt_secret" toml:"jwt_secret"`
+	// TrashRetentionDays is how long deleted habits stay in the trash
+	// before they are purged. 0 keeps them forever.
+	TrashRetentionDays int `yaml:"trash_retention_days" toml:"trash_retention_days"`
+	// UndoWindowMinutes is how long after a change it can be undone. 0
+	// disables undo.
+	UndoWindowMinutes int      `yaml:"undo_window_minutes" toml:"undo_window_minutes"`
+	Features          Features `yaml:"features" toml:"features"`
+}
+
+// Features toggles optional parts of the server.
+type Features struct {
+	// Swagger serves the API documentation under /swagger.
+	Swagger bool `yaml:"swagger" toml:"swagger"`
+	// Registration allows new users to sign up through /auth/register.
+	Registration bool `yaml:"registration" toml:"registration"`
+}
+
+// Default returns the configuration used for settings that are neither in
+// the file nor in the environment.
+func Default() Config {
+	return Config{
+		DatabaseDriver:     "postgres",
+		ListenAddr:         ":8080",
+		GinMode:            "debug",
+		TemplateGlob:       "templates/*",
+		LogLevel:           "info",
+		TrashRetentionDays: 30,
+		UndoWindowMinutes:  10,
+		Features: Features{
+			Swagger:      true,
+			Registration: true,
+		},
+	}
+}
+
+// Load reads the file at path, if not empty, applies the environment on top
+// and validates the result. All problems are reported at once.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return cfg, err
+		}
+	}
+	if err := errors.Join(cfg.readEnv(), cfg.Validate()); err != nil {
+		return cfg, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// readFile decodes a YAML or TOML file, chosen by its extension.
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("config file %s: unsupported format %q, use .yaml or .toml", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// readEnv overrides settings with the environment variables that are set
+// and not empty.
+func (c *Config) readEnv() error {
+	strs := map[string]*string{
+		"DATABASE_DRIVER": &c.DatabaseDriver,
+		"DATABASE_URL":    &c.DatabaseURL,
+		"LISTEN_ADDR":     &c.ListenAddr,
+		"GIN_MODE":        &c.GinMode,
+		"TEMPLATE_GLOB":   &c.TemplateGlob,
+		"LOG_LEVEL":       &c.LogLevel,
+		"JWT_SECRET":      &c.JWTSecret,
+	}
+	for name, dst := range strs {
+		if v := os.Getenv(name); v != "" {
+			*dst = v
+		}
+	}
+	if v := os.Getenv("CORS_ORIGINS"); v != "" {
+		c.CORSOrigins = nil
+		for _, o := range strings.Split(v, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				c.CORSOrigins = append(c.CORSOrigins, o)
+			}
+		}
+	}
+	bools := map[string]*bool{
+		"FEATURE_SWAGGER":      &c.Features.Swagger,
+		"FEATURE_REGISTRATION": &c.Features.Registration,
+	}
+	var errs []error
+	for name, dst := range bools {
+		if v := os.Getenv(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a boolean", name, v))
+				continue
+			}
+			*dst = b
+		}
+	}
+	ints := map[string]*int{
+		"TRASH_RETENTION_DAYS": &c.TrashRetentionDays,
+		"UNDO_WINDOW_MINUTES":  &c.UndoWindowMinutes,
+	}
+	for name, dst := range ints {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not an integer", name, v))
+				continue
+			}
+			*dst = n
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks every setting and returns all problems joined.
+func (c Config) Validate() error {
+	var errs []error
+	switch c.DatabaseDriver {
+	case "postgres", "sqlite":
+	default:
+		errs = append(errs, fmt.Errorf("database_driver (DATABASE_DRIVER) %q must be postgres or sqlite", c.DatabaseDriver))
+	}
+	if c.DatabaseURL == "" {
+		errs = append(errs, errors.New("database_url (DATABASE_URL) is required"))
+	}
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("listen_addr (LISTEN_ADDR) %q: %v", c.ListenAddr, err))
+	}
+	switch c.GinMode {
+	case "debug", "release", "test":
+	default:
+		errs = append(errs, fmt.Errorf("gin_mode (GIN_MODE) %q must be debug, release or test", c.GinMode))
+	}
+	// An empty template_glob turns the web UI off
+	if c.TemplateGlob != "" {
+		if matches, err := filepath.Glob(c.TemplateGlob); err != nil || len(matches) == 0 {
+			errs = append(errs, fmt.Errorf("template_glob (TEMPLATE_GLOB) %q matches no files", c.TemplateGlob))
+		}
+	}
+	for _, o := range c.CORSOrigins {
+		if o == "*" {
+			continue
+		}
+		if u, err := url.Parse(o); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			errs = append(errs, fmt.Errorf("cors_origins (CORS_ORIGINS): %q is not an origin like https://example.com", o))
+		}
+	}
+	if _, err := c.SlogLevel(); err != nil {
+		errs = append(errs, fmt.Errorf("log_level (LOG_LEVEL): %v", err))
+	}
+	if c.TrashRetentionDays < 0 {
+		errs = append(errs, fmt.Errorf("trash_retention_days (TRASH_RETENTION_DAYS) %d must not be negative", c.TrashRetentionDays))
+	}
+	if c.UndoWindowMinutes < 0 {
+		errs = append(errs, fmt.Errorf("undo_window_minutes (UNDO_WINDOW_MINUTES) %d must not be negative", c.UndoWindowMinutes))
+	}
+	if c.GinMode == "release" && c.JWTSecret == "" {
+		errs = append(errs, errors.New("jwt_secret (JWT_SECRET) is required in release mode"))
+	}
+	return errors.Join(errs...)
+}
+
+// SlogLevel returns the configured log level.
+func (c Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(c.LogLevel))
+	return level, err
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplateGlob(t *testing.T) {
+	tests := []struct {
+		glob    string
+		wantErr bool
+	}{
+		{glob: "", wantErr: false},
+		{glob: "../templates/*", wantErr: false},
+		{glob: "../templates/*.missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.DatabaseURL = ":memory:"
+		cfg.TemplateGlob = tt.glob
+		err := cfg.Validate()
+		if got := err != nil && strings.Contains(err.Error(), "template_glob"); got != tt.wantErr {
+			t.Errorf("Validate() with template_glob %q: got error %v, want error %v", tt.glob, err, tt.wantErr)
+		}
+	}
+}
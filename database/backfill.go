@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// backfillNameKeys sets the name key of the habits that have none, such as
+// those that existed before the column was added. The keys are computed
+// with schema.HabitNameKey, like the hooks do, since the case folding of
+// SQL lower() depends on the database and its locale. A habit outside the
+// trash whose key an older habit of the same owner already has is renamed
+// to "<name> (<id>)" first.
+func backfillNameKeys(ctx context.Context, db *sql.DB, driver string) error {
+	d := dialect.SQLite
+	if driver == Postgres {
+		d = dialect.Postgres
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	type owned struct {
+		owner int
+		key   string
+	}
+	taken := make(map[owned]bool)
+	query, args := entsql.Dialect(d).
+		Select(habit.FieldOwnerID, habit.FieldNameKey).
+		From(entsql.Table(habit.Table)).
+		Where(entsql.And(entsql.NotNull(habit.FieldNameKey), entsql.IsNull(habit.FieldDeletedAt))).
+		Query()
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var o owned
+		if err := rows.Scan(&o.owner, &o.key); err != nil {
+			rows.Close()
+			return err
+		}
+		taken[o] = true
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	type missing struct {
+		id, owner int
+		name      string
+		trashed   bool
+	}
+	var habits []missing
+	query, args = entsql.Dialect(d).
+		Select(habit.FieldID, habit.FieldOwnerID, habit.FieldName, habit.FieldDeletedAt).
+		From(entsql.Table(habit.Table)).
+		Where(entsql.IsNull(habit.FieldNameKey)).
+		OrderBy(habit.FieldID).
+		Query()
+	rows, err = tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var h missing
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&h.id, &h.owner, &h.name, &deletedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		h.trashed = deletedAt.Valid
+		habits = append(habits, h)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, h := range habits {
+		key := schema.HabitNameKey(h.name)
+		if !h.trashed {
+			if taken[owned{h.owner, key}] {
+				h.name = fmt.Sprintf("%s (%d)", h.name, h.id)
+				key = schema.HabitNameKey(h.name)
+			}
+			taken[owned{h.owner, key}] = true
+		}
+		query, args := entsql.Dialect(d).
+			Update(habit.Table).
+			Set(habit.FieldName, h.name).
+			Set(habit.FieldNameKey, key).
+			Where(entsql.EQ(habit.FieldID, h.id)).
+			Query()
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("backfilling the name key of habit %d: %w", h.id, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,75 @@
+// Package database opens ent clients for the supported SQL backends.
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"api/audit"
+	"api/ent"
+	_ "api/ent/runtime" // schema hooks
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Supported values of the database_driver setting.
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// Open connects to the database of the given driver. SQLite accepts a file
+// path, a "file:" URI or ":memory:" for a throwaway in-memory database.
+// Times are stored in UTC on SQLite, and changes made through the client are
+// recorded in the audit log.
+func Open(driver, dsn string) (*ent.Client, error) {
+	var drv *sql.Driver
+	var err error
+	switch driver {
+	case Postgres:
+		drv, err = sql.Open(dialect.Postgres, dsn)
+	case SQLite:
+		drv, err = sql.Open(dialect.SQLite, SQLiteDSN(dsn))
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var d dialect.Driver = drv
+	if driver == SQLite {
+		d = utcDriver{drv}
+	}
+	client := ent.NewClient(ent.Driver(audit.Driver(d)))
+	client.Use(audit.Hook())
+	return client, nil
+}
+
+// SQLiteDSN turns a path or URI into a go-sqlite3 data source. Foreign keys
+// are switched on because ent relies on them for cascading deletes, and
+// writers wait for locks instead of failing right away.
+func SQLiteDSN(dsn string) string {
+	if dsn == ":memory:" {
+		// Every pooled connection must see the same in-memory database.
+		dsn = "file::memory:?cache=shared"
+	}
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn
+	}
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return dsn
+	}
+	if query.Get("_fk") == "" && query.Get("_foreign_keys") == "" {
+		query.Set("_fk", "1")
+	}
+	if query.Get("_busy_timeout") == "" && query.Get("_timeout") == "" {
+		query.Set("_busy_timeout", "5000")
+	}
+	return path + "?" + query.Encode()
+}
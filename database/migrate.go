@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"api/ent/migrate/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	mdatabase "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// ErrSchemaBehind is returned by CheckSchema when migrations are pending.
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Migrator applies the embedded versioned migrations of a driver. Applied
+// versions are recorded in the schema_migrations table, compatible with the
+// golang-migrate CLI.
+type Migrator struct {
+	m      *migrate.Migrate
+	src    source.Driver
+	db     *sql.DB
+	driver string
+}
+
+// MigrationStatus describes where a database stands compared to the
+// migrations embedded in the binary.
+type MigrationStatus struct {
+	// Current is the applied version, 0 when nothing was applied yet.
+	Current uint
+	// Latest is the newest embedded migration.
+	Latest uint
+	// Dirty is set when a migration failed halfway and must be fixed by
+	// hand, then marked with "migrate force".
+	Dirty bool
+	// Pending lists the embedded versions newer than Current.
+	Pending []uint
+}
+
+// NewMigrator connects to the database with its own connection, which is
+// released by Close.
+func NewMigrator(driver, dsn string) (*Migrator, error) {
+	src, err := iofs.New(migrations.FS, driver)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+	var db *sql.DB
+	var target mdatabase.Driver
+	switch driver {
+	case Postgres:
+		if db, err = sql.Open("postgres", dsn); err == nil {
+			target, err = postgres.WithInstance(db, &postgres.Config{})
+		}
+	case SQLite:
+		if db, err = sql.Open("sqlite3", SQLiteDSN(dsn)); err == nil {
+			target, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+		}
+	}
+	if err != nil {
+		if db != nil {
+			db.Close()
+		}
+		src.Close()
+		return nil, err
+	}
+	m, err := migrate.NewWithInstance("iofs", src, driver, target)
+	if err != nil {
+		target.Close()
+		src.Close()
+		return nil, err
+	}
+	return &Migrator{m: m, src: src, db: db, driver: driver}, nil
+}
+
+// Up applies every pending migration, then fills in the data that SQL
+// cannot compute the way the application does.
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return backfillNameKeys(context.Background(), mg.db, mg.driver)
+}
+
+// Down reverts the most recently applied migration.
+func (mg *Migrator) Down() error {
+	return mg.m.Steps(-1)
+}
+
+// Force records version as applied and clears the dirty flag without
+// running anything, e.g. to adopt a database created by auto-migration.
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Status compares the applied version with the embedded migrations.
+func (mg *Migrator) Status() (MigrationStatus, error) {
+	var st MigrationStatus
+	current, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return st, err
+	}
+	st.Current, st.Dirty = current, dirty
+	v, err := mg.src.First()
+	for err == nil {
+		st.Latest = v
+		if v > st.Current {
+			st.Pending = append(st.Pending, v)
+		}
+		v, err = mg.src.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, fs.ErrNotExist) {
+		return st, err
+	}
+	return st, nil
+}
+
+// Close releases the connection of the migrator.
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// CheckSchema returns ErrSchemaBehind when the database is missing
+// migrations embedded in the binary, and an error when it is dirty.
+func CheckSchema(driver, dsn string) error {
+	mg, err := NewMigrator(driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer mg.Close()
+	st, err := mg.Status()
+	if err != nil {
+		return err
+	}
+	if st.Dirty {
+		return fmt.Errorf("database schema version %d is dirty, fix it and run \"migrate force\"", st.Current)
+	}
+	if len(st.Pending) > 0 {
+		return fmt.Errorf("%w: at version %d, expected %d; run \"migrate up\"", ErrSchemaBehind, st.Current, st.Latest)
+	}
+	return nil
+}
+
+// InMemory reports whether dsn is a throwaway SQLite database, which starts
+// empty on every boot and is created from the ent schema instead.
+func InMemory(driver, dsn string) bool {
+	return driver == SQLite && (dsn == ":memory:" || strings.Contains(dsn, "mode=memory"))
+}
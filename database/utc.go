@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// utcDriver passes times to SQLite in UTC. go-sqlite3 stores a time.Time as
+// text in the offset of the value and SQLite compares the text, so times
+// written or bound in different offsets would not sort chronologically.
+type utcDriver struct {
+	dialect.Driver
+}
+
+func (d utcDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Exec(ctx, query, utcArgs(args), v)
+}
+
+func (d utcDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Query(ctx, query, utcArgs(args), v)
+}
+
+func (d utcDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return utcTx{tx}, nil
+}
+
+type utcTx struct {
+	dialect.Tx
+}
+
+func (tx utcTx) Exec(ctx context.Context, query string, args, v any) error {
+	return tx.Tx.Exec(ctx, query, utcArgs(args), v)
+}
+
+func (tx utcTx) Query(ctx context.Context, query string, args, v any) error {
+	return tx.Tx.Query(ctx, query, utcArgs(args), v)
+}
+
+// utcArgs converts the times among the arguments of a statement to UTC.
+func utcArgs(args any) any {
+	values, ok := args.([]any)
+	if !ok {
+		return args
+	}
+	var converted []any
+	for i, v := range values {
+		t, ok := v.(time.Time)
+		if !ok {
+			continue
+		}
+		if converted == nil {
+			converted = append([]any(nil), values...)
+		}
+		converted[i] = t.UTC()
+	}
+	if converted == nil {
+		return args
+	}
+	return converted
+}
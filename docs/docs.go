@@ -15,24 +15,93 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/habits": {
+        "/audit": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes to the user's habits and check-ins, newest first. Each event holds the values of the changed fields before and after the change.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get all habits",
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "habit",
+                            "checkin"
+                        ],
+                        "type": "string",
+                        "description": "Only events of this entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events of this entity",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes made by this user",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the Link header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/ent.Habit"
+                                "$ref": "#/definitions/ent.AuditEvent"
                             }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Next page, if any"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -40,15 +109,15 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create a new habit",
+                "summary": "Log in and obtain a JWT access token and a refresh token",
                 "parameters": [
                     {
-                        "description": "Habit to create",
-                        "name": "habit",
+                        "description": "Email and password",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ent.Habit"
+                            "$ref": "#/definitions/server.Credentials"
                         }
                     }
                 ],
@@ -56,59 +125,213 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ent.Habit"
+                            "$ref": "#/definitions/server.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
                         }
                     }
                 }
             }
         },
-        "/habits/{id}": {
+        "/auth/logout": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Revoke a refresh token and every token rotated from the same login",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a single habit",
+                "summary": "Get the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "The refresh token is rotated: it can only be used once, and replaying a used token revokes every token issued from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Exchange a refresh token for a new token pair",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ent.Habit"
+                            "$ref": "#/definitions/server.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/register": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update a habit",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Email, password (at least 8 characters) and optional time zone",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.Credentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/session": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Log in the HTML page with a session cookie",
+                "parameters": [
                     {
-                        "description": "Habit to update",
-                        "name": "habit",
+                        "description": "Email and password",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/ent.Habit"
+                            "$ref": "#/definitions/server.Credentials"
                         }
                     }
                 ],
@@ -116,7 +339,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ent.Habit"
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
                         }
                     }
                 }
@@ -125,48 +366,3038 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete a habit",
+                "summary": "Log out the HTML page",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Habits are returned a page at a time. When there are more, the Link header points to the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get all habits",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 50,
+                        "description": "Page size, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor taken from the Link header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "name",
+                            "streak"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort key, streak sorts habits to quit by days since the last slip",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction (default: desc for created_at and streak, asc for name)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only habits whose name contains this text, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only habits created after this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only habits created before this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "List archived instead of active habits",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only habits with all of these tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone used to compute streaks (default: user time zone)",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.HabitResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "\u003c...\u003e; rel=\\\"next\\\" when there are more habits"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "ent.Habit": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "CreatedAt holds the value of the \"created_at\" field.",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "Description holds the value of the \"description\" field.",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "ID of the ent.",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "Name holds the value of the \"name\" field.",
-                    "type": "string"
-                }
-            }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Text fields are trimmed and all invalid fields are reported at once. The name must be unique among the user's habits, ignoring case: a taken name is a 409 with the existing_id of the habit, or with upsert=true that habit is returned unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a new habit",
+                "parameters": [
+                    {
+                        "description": "Habit to create",
+                        "name": "habit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.HabitInput"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the existing habit of the same name instead of failing",
+                        "name": "upsert",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries return the first response instead of creating another habit",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the habit"
+                            },
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true if the response is the stored one of an earlier request with the same key"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/due": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Weekly and monthly habits are due until they have been checked in often enough in the current period. Numeric and duration habits report the values checked in on the day, or in the period so far, as progress, and are done once it meets their target. Habits to quit are never due.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the habits due on a day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Day to check as YYYY-MM-DD (default today)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of the day (default: user time zone)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.DueHabit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleted habits stay in the trash for the configured retention period and can be restored until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List deleted habits",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.TrashedHabit"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a single habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone used to compute streaks (default: user time zone)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.HabitResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the habit and its streak"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The cached copy is current"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces every editable field, validated like on create.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Habit to update",
+                        "name": "habit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.HabitInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only update if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the habit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the habit to the trash, from where it can be restored until it is purged. The response holds the event_id to pass to POST /undo.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only delete if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a JSON Merge Patch (RFC 7396, application/merge-patch+json or application/json) or a JSON Patch (RFC 6902, application/json-patch+json). Fields set to null or removed are cleared; fields left out of a merge patch are kept.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Partially update a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only update if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the habit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archived habits are hidden from the habit list and the due list but keep their check-ins, streaks and search results.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Archive a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only archive if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the habit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/checkins": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dates accept either YYYY-MM-DD or RFC 3339; both bounds are inclusive.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List check-ins of a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest check-in date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest check-in date",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ent.Completion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check-ins of numeric and duration habits carry a value. A day counts as done once the values checked in that day meet the target of the habit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check in a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Check-in to record",
+                        "name": "checkin",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/server.CheckinInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries return the first response instead of checking in again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Completion"
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true if the response is the stored one of an earlier request with the same key"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/checkins/{checkinId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The response holds the event_id to pass to POST /undo.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete a check-in",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Check-in ID",
+                        "name": "checkinId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a habit out of the trash, together with its check-ins. Fails with 409 if another habit has taken its name in the meantime.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Restore a deleted habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only restore if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the habit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Completion rates, totals and trends over a range of days, aggregated by the database. A day is done when its check-ins meet the target of the habit, which a day without check-ins does for an at_most target; for a habit to quit, when it has no slip. Rates divide the done days by the days the habit was due, with weekly and monthly quotas spread evenly over their period, and only count days from the creation of the habit up to today.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the statistics of a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day as YYYY-MM-DD (default: a year before to, or the creation of the habit if later but not after to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day as YYYY-MM-DD (default today)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "week",
+                        "description": "Length of the periods",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of the days (default: user time zone)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.HabitStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unarchive a habit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only unarchive if the habit still has this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ent.Habit"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the habit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/habits:batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates, updates, deletes, archives and unarchives up to 100 habits in one request. In all_or_nothing mode the operations run in a single transaction: if one fails, none is applied and the response is the problem of the failed operation, whose index is in the detail and the field names. In per_item mode every operation runs in its own transaction and gets its own status in the results; the response is a 207 if any of them failed, and is replayed like any other for its Idempotency-Key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Apply several habit operations at once",
+                "parameters": [
+                    {
+                        "description": "Operations to apply in order",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.BatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries return the first response instead of applying the operations again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.BatchResponse"
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true if the response is the stored one of an earlier request with the same key"
+                            }
+                        }
+                    },
+                    "207": {
+                        "description": "Some operations of a per_item batch failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Matches habit names and descriptions and check-in notes. Postgres ranks with full-text search, other databases match every word as a substring.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Search habits and check-in notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of results, 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/server.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List personal API tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ent.APIToken"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "API tokens cannot be used to manage API tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a personal API token",
+                "parameters": [
+                    {
+                        "description": "Name, scopes (habits:read, habits:write, checkins:read, checkins:write) and optional expiry",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/server.TokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/server.CreatedToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Revoke a personal API token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/undo/{eventId}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reverts a habit update or deletion, or a check-in deletion, recorded in the audit log. Only possible within the undo window, while the habit has not changed since, and once per event.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Undo a change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Audit event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The restored habit or check-in",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auditevent.Operation": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "OperationCreate",
+                "OperationUpdate",
+                "OperationDelete"
+            ]
+        },
+        "ent.APIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the APITokenQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.APITokenEdges"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "description": "ExpiresAt holds the value of the \"expires_at\" field.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt holds the value of the \"last_used_at\" field.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix holds the value of the \"prefix\" field.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes holds the value of the \"scopes\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "description": "UserID holds the value of the \"user_id\" field.",
+                    "type": "integer"
+                }
+            }
+        },
+        "ent.APITokenEdges": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "description": "User holds the value of the user edge.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "ent.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "ActorID holds the value of the \"actor_id\" field.",
+                    "type": "integer"
+                },
+                "after": {
+                    "description": "After holds the value of the \"after\" field.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "description": "Before holds the value of the \"before\" field.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "EntityID holds the value of the \"entity_id\" field.",
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "description": "EntityType holds the value of the \"entity_type\" field.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "operation": {
+                    "description": "Operation holds the value of the \"operation\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/auditevent.Operation"
+                        }
+                    ]
+                },
+                "owner_id": {
+                    "description": "OwnerID holds the value of the \"owner_id\" field.",
+                    "type": "integer"
+                },
+                "undo_of": {
+                    "description": "UndoOf holds the value of the \"undo_of\" field.",
+                    "type": "integer"
+                }
+            }
+        },
+        "ent.Completion": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "CompletedAt holds the value of the \"completed_at\" field.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the CompletionQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.CompletionEdges"
+                        }
+                    ]
+                },
+                "habit_id": {
+                    "description": "HabitID holds the value of the \"habit_id\" field.",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Note holds the value of the \"note\" field.",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value holds the value of the \"value\" field.",
+                    "type": "number"
+                }
+            }
+        },
+        "ent.CompletionEdges": {
+            "type": "object",
+            "properties": {
+                "habit": {
+                    "description": "Habit holds the value of the habit edge.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.Habit"
+                        }
+                    ]
+                }
+            }
+        },
+        "ent.Habit": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Archived holds the value of the \"archived\" field.",
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Color holds the value of the \"color\" field.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt holds the value of the \"deleted_at\" field.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description holds the value of the \"description\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the HabitQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.HabitEdges"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "interval_days": {
+                    "description": "IntervalDays holds the value of the \"interval_days\" field.",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID holds the value of the \"owner_id\" field.",
+                    "type": "integer"
+                },
+                "polarity": {
+                    "description": "Polarity holds the value of the \"polarity\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Polarity"
+                        }
+                    ]
+                },
+                "rrule": {
+                    "description": "Rrule holds the value of the \"rrule\" field.",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule holds the value of the \"schedule\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Schedule"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags holds the value of the \"tags\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target holds the value of the \"target\" field.",
+                    "type": "number"
+                },
+                "target_direction": {
+                    "description": "TargetDirection holds the value of the \"target_direction\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.TargetDirection"
+                        }
+                    ]
+                },
+                "times_per_period": {
+                    "description": "TimesPerPeriod holds the value of the \"times_per_period\" field.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type holds the value of the \"type\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Type"
+                        }
+                    ]
+                },
+                "unit": {
+                    "description": "Unit holds the value of the \"unit\" field.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt holds the value of the \"updated_at\" field.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version holds the value of the \"version\" field.",
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "description": "Weekdays holds the value of the \"weekdays\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "ent.HabitEdges": {
+            "type": "object",
+            "properties": {
+                "completions": {
+                    "description": "Completions holds the value of the completions edge.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ent.Completion"
+                    }
+                },
+                "owner": {
+                    "description": "Owner holds the value of the owner edge.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "ent.IdempotencyKey": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Body holds the value of the \"body\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the IdempotencyKeyQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.IdempotencyKeyEdges"
+                        }
+                    ]
+                },
+                "header": {
+                    "description": "Header holds the value of the \"header\" field.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Key holds the value of the \"key\" field.",
+                    "type": "string"
+                },
+                "request_hash": {
+                    "description": "RequestHash holds the value of the \"request_hash\" field.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status holds the value of the \"status\" field.",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID holds the value of the \"user_id\" field.",
+                    "type": "integer"
+                }
+            }
+        },
+        "ent.IdempotencyKeyEdges": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "description": "User holds the value of the user edge.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "ent.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the SessionQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.SessionEdges"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "description": "ExpiresAt holds the value of the \"expires_at\" field.",
+                    "type": "string"
+                },
+                "family": {
+                    "description": "Family holds the value of the \"family\" field.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Kind holds the value of the \"kind\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/session.Kind"
+                        }
+                    ]
+                },
+                "revoked_at": {
+                    "description": "RevokedAt holds the value of the \"revoked_at\" field.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID holds the value of the \"user_id\" field.",
+                    "type": "integer"
+                }
+            }
+        },
+        "ent.SessionEdges": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "description": "User holds the value of the user edge.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "ent.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the UserQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.UserEdges"
+                        }
+                    ]
+                },
+                "email": {
+                    "description": "Email holds the value of the \"email\" field.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "timezone": {
+                    "description": "Timezone holds the value of the \"timezone\" field.",
+                    "type": "string"
+                }
+            }
+        },
+        "ent.UserEdges": {
+            "type": "object",
+            "properties": {
+                "api_tokens": {
+                    "description": "APITokens holds the value of the api_tokens edge.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ent.APIToken"
+                    }
+                },
+                "habits": {
+                    "description": "Habits holds the value of the habits edge.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ent.Habit"
+                    }
+                },
+                "idempotency_keys": {
+                    "description": "IdempotencyKeys holds the value of the idempotency_keys edge.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ent.IdempotencyKey"
+                    }
+                },
+                "sessions": {
+                    "description": "Sessions holds the value of the sessions edge.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ent.Session"
+                    }
+                }
+            }
+        },
+        "habit.Polarity": {
+            "type": "string",
+            "enum": [
+                "build",
+                "build",
+                "quit"
+            ],
+            "x-enum-varnames": [
+                "DefaultPolarity",
+                "PolarityBuild",
+                "PolarityQuit"
+            ]
+        },
+        "habit.Schedule": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "daily",
+                "weekdays",
+                "weekly",
+                "monthly",
+                "interval",
+                "rrule"
+            ],
+            "x-enum-varnames": [
+                "DefaultSchedule",
+                "ScheduleDaily",
+                "ScheduleWeekdays",
+                "ScheduleWeekly",
+                "ScheduleMonthly",
+                "ScheduleInterval",
+                "ScheduleRrule"
+            ]
+        },
+        "habit.TargetDirection": {
+            "type": "string",
+            "enum": [
+                "at_least",
+                "at_most"
+            ],
+            "x-enum-varnames": [
+                "TargetDirectionAtLeast",
+                "TargetDirectionAtMost"
+            ]
+        },
+        "habit.Type": {
+            "type": "string",
+            "enum": [
+                "boolean",
+                "boolean",
+                "numeric",
+                "duration"
+            ],
+            "x-enum-varnames": [
+                "DefaultType",
+                "TypeBoolean",
+                "TypeNumeric",
+                "TypeDuration"
+            ]
+        },
+        "server.BatchOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "habit": {
+                    "description": "Habit is the habit to create, or the new fields of the habit to\nupdate. It is validated like the body of POST /habits.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.HabitInput"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID is the habit to change, for every operation but create.",
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "archive",
+                        "unarchive"
+                    ],
+                    "example": "create"
+                },
+                "version": {
+                    "description": "Version makes the operation fail with 412 if the habit has another\nversion, like If-Match.",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.BatchRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "Mode all_or_nothing, the default, applies every operation or none;\nper_item applies each operation on its own.",
+                    "type": "string",
+                    "enum": [
+                        "all_or_nothing",
+                        "per_item"
+                    ],
+                    "example": "all_or_nothing"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/server.BatchOperation"
+                    }
+                }
+            }
+        },
+        "server.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.BatchResult"
+                    }
+                }
+            }
+        },
+        "server.BatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error tells why the operation failed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.Problem"
+                        }
+                    ]
+                },
+                "event_id": {
+                    "description": "EventID is the audit event of a delete, to pass to POST /undo.",
+                    "type": "integer"
+                },
+                "habit": {
+                    "description": "Habit is the created or changed habit, unset for delete.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.Habit"
+                        }
+                    ]
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "server.CheckinInput": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "CompletedAt defaults to the time of the request when omitted.",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the amount done, required for numeric habits (in their unit)\nand duration habits (in minutes) and not accepted for boolean ones.\nThe values of a day add up towards the target of the habit.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2.5
+                }
+            }
+        },
+        "server.CreatedToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the APITokenQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.APITokenEdges"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "description": "ExpiresAt holds the value of the \"expires_at\" field.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt holds the value of the \"last_used_at\" field.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix holds the value of the \"prefix\" field.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes holds the value of the \"scopes\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "UserID holds the value of the \"user_id\" field.",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.Credentials": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is only used on registration and defaults to UTC.",
+                    "type": "string"
+                }
+            }
+        },
+        "server.DueHabit": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Archived holds the value of the \"archived\" field.",
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Color holds the value of the \"color\" field.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt holds the value of the \"deleted_at\" field.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description holds the value of the \"description\" field.",
+                    "type": "string"
+                },
+                "done": {
+                    "description": "Done reports whether the check-ins of that day meet the target so\nfar, or those of the period up to that day for weekly and monthly\nhabits.",
+                    "type": "boolean"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the HabitQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.HabitEdges"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "interval_days": {
+                    "description": "IntervalDays holds the value of the \"interval_days\" field.",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID holds the value of the \"owner_id\" field.",
+                    "type": "integer"
+                },
+                "polarity": {
+                    "description": "Polarity holds the value of the \"polarity\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Polarity"
+                        }
+                    ]
+                },
+                "progress": {
+                    "description": "Progress is the sum of the values checked in on that day, or in the\nperiod up to that day, for numeric and duration habits.",
+                    "type": "number"
+                },
+                "rrule": {
+                    "description": "Rrule holds the value of the \"rrule\" field.",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule holds the value of the \"schedule\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Schedule"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags holds the value of the \"tags\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target holds the value of the \"target\" field.",
+                    "type": "number"
+                },
+                "target_direction": {
+                    "description": "TargetDirection holds the value of the \"target_direction\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.TargetDirection"
+                        }
+                    ]
+                },
+                "times_per_period": {
+                    "description": "TimesPerPeriod holds the value of the \"times_per_period\" field.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type holds the value of the \"type\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Type"
+                        }
+                    ]
+                },
+                "unit": {
+                    "description": "Unit holds the value of the \"unit\" field.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt holds the value of the \"updated_at\" field.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version holds the value of the \"version\" field.",
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "description": "Weekdays holds the value of the \"weekdays\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "server.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "is required"
+                }
+            }
+        },
+        "server.HabitInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Color is a hex color like \"#1e90ff\".",
+                    "type": "string",
+                    "example": "#1e90ff"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "interval_days": {
+                    "description": "IntervalDays for the \"interval\" schedule.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Read"
+                },
+                "polarity": {
+                    "description": "Polarity is build, or quit for a habit whose check-ins are slips. A\nhabit to quit must be daily and boolean.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Polarity"
+                        }
+                    ],
+                    "example": "build"
+                },
+                "rrule": {
+                    "description": "Rrule is an RFC 5545 rule for the \"rrule\" schedule.",
+                    "type": "string"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/habit.Schedule"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target is what the values checked in on a day must add up to, at\nleast or at most depending on TargetDirection. Required for measured\nhabits.",
+                    "type": "number",
+                    "example": 5
+                },
+                "target_direction": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.TargetDirection"
+                        }
+                    ],
+                    "example": "at_least"
+                },
+                "times_per_period": {
+                    "description": "TimesPerPeriod for the \"weekly\" and \"monthly\" schedules.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is boolean, numeric or duration. Check-ins of measured habits\ncarry a value, in Unit for numeric and in minutes for duration habits.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Type"
+                        }
+                    ],
+                    "example": "boolean"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "km"
+                },
+                "weekdays": {
+                    "description": "Weekdays (0 = Sunday) for the \"weekdays\" schedule.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "server.HabitResponse": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Archived holds the value of the \"archived\" field.",
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Color holds the value of the \"color\" field.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "current_streak": {
+                    "type": "integer"
+                },
+                "days_since_last_slip": {
+                    "description": "DaysSinceLastSlip counts the days since the last slip, or since the\nhabit was created if there was none.",
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt holds the value of the \"deleted_at\" field.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description holds the value of the \"description\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the HabitQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.HabitEdges"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "interval_days": {
+                    "description": "IntervalDays holds the value of the \"interval_days\" field.",
+                    "type": "integer"
+                },
+                "last_completed": {
+                    "type": "string"
+                },
+                "last_slip": {
+                    "type": "string"
+                },
+                "longest_clean_stretch": {
+                    "description": "LongestCleanStretch is the most days between two slips, counting the\ntime before the first and since the last.",
+                    "type": "integer"
+                },
+                "longest_streak": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID holds the value of the \"owner_id\" field.",
+                    "type": "integer"
+                },
+                "polarity": {
+                    "description": "Polarity holds the value of the \"polarity\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Polarity"
+                        }
+                    ]
+                },
+                "rrule": {
+                    "description": "Rrule holds the value of the \"rrule\" field.",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule holds the value of the \"schedule\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Schedule"
+                        }
+                    ]
+                },
+                "slip_trend": {
+                    "description": "SlipTrend compares the slips of the last 4 weeks with the 4 before:\n\"improving\", \"steady\" or \"worsening\". Unset while the habit is\nyounger than 8 weeks.",
+                    "type": "string",
+                    "enum": [
+                        "improving",
+                        "steady",
+                        "worsening"
+                    ]
+                },
+                "tags": {
+                    "description": "Tags holds the value of the \"tags\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target holds the value of the \"target\" field.",
+                    "type": "number"
+                },
+                "target_direction": {
+                    "description": "TargetDirection holds the value of the \"target_direction\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.TargetDirection"
+                        }
+                    ]
+                },
+                "times_per_period": {
+                    "description": "TimesPerPeriod holds the value of the \"times_per_period\" field.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type holds the value of the \"type\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Type"
+                        }
+                    ]
+                },
+                "unit": {
+                    "description": "Unit holds the value of the \"unit\" field.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt holds the value of the \"updated_at\" field.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version holds the value of the \"version\" field.",
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "description": "Weekdays holds the value of the \"weekdays\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "weekly_slips": {
+                    "description": "WeeklySlips counts the slips of each of the last 8 weeks, oldest\nfirst. Weeks end today.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "server.HabitStats": {
+            "type": "object",
+            "properties": {
+                "average_value": {
+                    "type": "number"
+                },
+                "best_weekday": {
+                    "description": "BestWeekday and WorstWeekday have the highest and lowest rate of the\nweekdays the habit was due on. Unset if the range has no check-ins.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/server.WeekdayStats"
+                        }
+                    ]
+                },
+                "done_days": {
+                    "description": "DoneDays and Rate are those of the whole range.",
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "granularity": {
+                    "type": "string",
+                    "example": "week"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.PeriodStats"
+                    }
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-12-31"
+                },
+                "total_completions": {
+                    "description": "TotalCompletions counts the check-ins in the range.",
+                    "type": "integer"
+                },
+                "worst_weekday": {
+                    "$ref": "#/definitions/server.WeekdayStats"
+                }
+            }
+        },
+        "server.PeriodStats": {
+            "type": "object",
+            "properties": {
+                "average_value": {
+                    "description": "AverageValue is the mean daily total of a numeric or duration habit\nover the days with check-ins.",
+                    "type": "number",
+                    "example": 2.5
+                },
+                "completions": {
+                    "description": "Completions counts the check-ins.",
+                    "type": "integer"
+                },
+                "done_days": {
+                    "description": "DoneDays counts the days whose check-ins meet the target, or for a\nhabit to quit the days without a slip.",
+                    "type": "integer"
+                },
+                "moving_average": {
+                    "description": "MovingAverage is the mean rate of the last 7 days, 4 weeks or 3\nmonths up to this period.",
+                    "type": "number",
+                    "example": 0.8
+                },
+                "rate": {
+                    "description": "Rate is DoneDays divided by the days the habit was due, from 0 to 1.\nUnset if the habit was not due in the period.",
+                    "type": "number",
+                    "example": 0.75
+                },
+                "start": {
+                    "description": "Start is the first day of the period.",
+                    "type": "string",
+                    "example": "2026-10-12"
+                }
+            }
+        },
+        "server.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is a stable, machine-readable error code.",
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Habit not found"
+                },
+                "errors": {
+                    "description": "Errors lists the invalid fields of a validation problem.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/server.FieldError"
+                    }
+                },
+                "existing_id": {
+                    "description": "ExistingID is the habit that already has the name, for conflicts\nover a habit name.",
+                    "type": "integer"
+                },
+                "instance": {
+                    "description": "Instance is the path of the request.",
+                    "type": "string",
+                    "example": "/habits/42"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "description": "Type is always about:blank; Code identifies the problem instead.",
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "server.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.SearchResult": {
+            "type": "object",
+            "properties": {
+                "checkin_id": {
+                    "type": "integer"
+                },
+                "habit_id": {
+                    "type": "integer"
+                },
+                "habit_name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "Rank orders the results, higher is more relevant.",
+                    "type": "number"
+                },
+                "snippet": {
+                    "description": "Snippet is an HTML excerpt with the matches wrapped in \u003cmark\u003e.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is habit or checkin.",
+                    "type": "string"
+                }
+            }
+        },
+        "server.TokenInput": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt is optional; tokens without it never expire.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "server.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "server.TrashedHabit": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Archived holds the value of the \"archived\" field.",
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Color holds the value of the \"color\" field.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt holds the value of the \"created_at\" field.",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt holds the value of the \"deleted_at\" field.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description holds the value of the \"description\" field.",
+                    "type": "string"
+                },
+                "edges": {
+                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the HabitQuery when eager-loading is set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ent.HabitEdges"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID of the ent.",
+                    "type": "integer"
+                },
+                "interval_days": {
+                    "description": "IntervalDays holds the value of the \"interval_days\" field.",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name holds the value of the \"name\" field.",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "OwnerID holds the value of the \"owner_id\" field.",
+                    "type": "integer"
+                },
+                "polarity": {
+                    "description": "Polarity holds the value of the \"polarity\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Polarity"
+                        }
+                    ]
+                },
+                "purge_at": {
+                    "description": "PurgeAt is when the habit is deleted for good, unset if the trash is\nkept forever.",
+                    "type": "string"
+                },
+                "rrule": {
+                    "description": "Rrule holds the value of the \"rrule\" field.",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule holds the value of the \"schedule\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Schedule"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags holds the value of the \"tags\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target holds the value of the \"target\" field.",
+                    "type": "number"
+                },
+                "target_direction": {
+                    "description": "TargetDirection holds the value of the \"target_direction\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.TargetDirection"
+                        }
+                    ]
+                },
+                "times_per_period": {
+                    "description": "TimesPerPeriod holds the value of the \"times_per_period\" field.",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type holds the value of the \"type\" field.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/habit.Type"
+                        }
+                    ]
+                },
+                "unit": {
+                    "description": "Unit holds the value of the \"unit\" field.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt holds the value of the \"updated_at\" field.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version holds the value of the \"version\" field.",
+                    "type": "integer"
+                },
+                "weekdays": {
+                    "description": "Weekdays holds the value of the \"weekdays\" field.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "server.WeekdayStats": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "example": 0.9
+                },
+                "weekday": {
+                    "description": "Weekday is 0 for Sunday to 6 for Saturday.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "session.Kind": {
+            "type": "string",
+            "enum": [
+                "refresh",
+                "cookie"
+            ],
+            "x-enum-varnames": [
+                "KindRefresh",
+                "KindCookie"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT access token from /auth/login or personal API token from /tokens, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,184 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api/ent/completion"
+	"api/ent/habit"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Completion is the model entity for the Completion schema.
+type Completion struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// HabitID holds the value of the "habit_id" field.
+	HabitID int `json:"habit_id,omitempty"`
+	// CompletedAt holds the value of the "completed_at" field.
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+	// Note holds the value of the "note" field.
+	Note string `json:"note,omitempty"`
+	// Value holds the value of the "value" field.
+	Value *float64 `json:"value,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the CompletionQuery when eager-loading is set.
+	Edges        CompletionEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// CompletionEdges holds the relations/edges for other nodes in the graph.
+type CompletionEdges struct {
+	// Habit holds the value of the habit edge.
+	Habit *Habit `json:"habit,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// HabitOrErr returns the Habit value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e CompletionEdges) HabitOrErr() (*Habit, error) {
+	if e.Habit != nil {
+		return e.Habit, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: habit.Label}
+	}
+	return nil, &NotLoadedError{edge: "habit"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Completion) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case completion.FieldValue:
+			values[i] = new(sql.NullFloat64)
+		case completion.FieldID, completion.FieldHabitID:
+			values[i] = new(sql.NullInt64)
+		case completion.FieldNote:
+			values[i] = new(sql.NullString)
+		case completion.FieldCompletedAt, completion.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Completion fields.
+func (c *Completion) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case completion.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			c.ID = int(value.Int64)
+		case completion.FieldHabitID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field habit_id", values[i])
+			} else if value.Valid {
+				c.HabitID = int(value.Int64)
+			}
+		case completion.FieldCompletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field completed_at", values[i])
+			} else if value.Valid {
+				c.CompletedAt = value.Time
+			}
+		case completion.FieldNote:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field note", values[i])
+			} else if value.Valid {
+				c.Note = value.String
+			}
+		case completion.FieldValue:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field value", values[i])
+			} else if value.Valid {
+				c.Value = new(float64)
+				*c.Value = value.Float64
+			}
+		case completion.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				c.CreatedAt = value.Time
+			}
+		default:
+			c.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// GetValue returns the ent.Value that was dynamically selected and assigned to the Completion.
+// This includes values selected through modifiers, order, etc.
+func (c *Completion) GetValue(name string) (ent.Value, error) {
+	return c.selectValues.Get(name)
+}
+
+// QueryHabit queries the "habit" edge of the Completion entity.
+func (c *Completion) QueryHabit() *HabitQuery {
+	return NewCompletionClient(c.config).QueryHabit(c)
+}
+
+// Update returns a builder for updating this Completion.
+// Note that you need to call Completion.Unwrap() before calling this method if this Completion
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (c *Completion) Update() *CompletionUpdateOne {
+	return NewCompletionClient(c.config).UpdateOne(c)
+}
+
+// Unwrap unwraps the Completion entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (c *Completion) Unwrap() *Completion {
+	_tx, ok := c.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Completion is not a transactional entity")
+	}
+	c.config.driver = _tx.drv
+	return c
+}
+
+// String implements the fmt.Stringer.
+func (c *Completion) String() string {
+	var builder strings.Builder
+	builder.WriteString("Completion(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", c.ID))
+	builder.WriteString("habit_id=")
+	builder.WriteString(fmt.Sprintf("%v", c.HabitID))
+	builder.WriteString(", ")
+	builder.WriteString("completed_at=")
+	builder.WriteString(c.CompletedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("note=")
+	builder.WriteString(c.Note)
+	builder.WriteString(", ")
+	if v := c.Value; v != nil {
+		builder.WriteString("value=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(c.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Completions is a parsable slice of Completion.
+type Completions []*Completion
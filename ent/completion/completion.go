@@ -0,0 +1,114 @@
+// Code generated by ent, DO NOT EDIT.
+
+package completion
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the completion type in the database.
+	Label = "completion"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldHabitID holds the string denoting the habit_id field in the database.
+	FieldHabitID = "habit_id"
+	// FieldCompletedAt holds the string denoting the completed_at field in the database.
+	FieldCompletedAt = "completed_at"
+	// FieldNote holds the string denoting the note field in the database.
+	FieldNote = "note"
+	// FieldValue holds the string denoting the value field in the database.
+	FieldValue = "value"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgeHabit holds the string denoting the habit edge name in mutations.
+	EdgeHabit = "habit"
+	// Table holds the table name of the completion in the database.
+	Table = "completions"
+	// HabitTable is the table that holds the habit relation/edge.
+	HabitTable = "completions"
+	// HabitInverseTable is the table name for the Habit entity.
+	// It exists in this package in order to avoid circular dependency with the "habit" package.
+	HabitInverseTable = "habits"
+	// HabitColumn is the table column denoting the habit relation/edge.
+	HabitColumn = "habit_id"
+)
+
+// Columns holds all SQL columns for completion fields.
+var Columns = []string{
+	FieldID,
+	FieldHabitID,
+	FieldCompletedAt,
+	FieldNote,
+	FieldValue,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCompletedAt holds the default value on creation for the "completed_at" field.
+	DefaultCompletedAt func() time.Time
+	// ValueValidator is a validator for the "value" field. It is called by the builders before save.
+	ValueValidator func(float64) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the Completion queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByHabitID orders the results by the habit_id field.
+func ByHabitID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHabitID, opts...).ToFunc()
+}
+
+// ByCompletedAt orders the results by the completed_at field.
+func ByCompletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCompletedAt, opts...).ToFunc()
+}
+
+// ByNote orders the results by the note field.
+func ByNote(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNote, opts...).ToFunc()
+}
+
+// ByValue orders the results by the value field.
+func ByValue(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldValue, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByHabitField orders the results by habit field.
+func ByHabitField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newHabitStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newHabitStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(HabitInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, HabitTable, HabitColumn),
+	)
+}
@@ -0,0 +1,303 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api/ent/completion"
+	"api/ent/habit"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CompletionCreate is the builder for creating a Completion entity.
+type CompletionCreate struct {
+	config
+	mutation *CompletionMutation
+	hooks    []Hook
+}
+
+// SetHabitID sets the "habit_id" field.
+func (cc *CompletionCreate) SetHabitID(i int) *CompletionCreate {
+	cc.mutation.SetHabitID(i)
+	return cc
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (cc *CompletionCreate) SetCompletedAt(t time.Time) *CompletionCreate {
+	cc.mutation.SetCompletedAt(t)
+	return cc
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (cc *CompletionCreate) SetNillableCompletedAt(t *time.Time) *CompletionCreate {
+	if t != nil {
+		cc.SetCompletedAt(*t)
+	}
+	return cc
+}
+
+// SetNote sets the "note" field.
+func (cc *CompletionCreate) SetNote(s string) *CompletionCreate {
+	cc.mutation.SetNote(s)
+	return cc
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (cc *CompletionCreate) SetNillableNote(s *string) *CompletionCreate {
+	if s != nil {
+		cc.SetNote(*s)
+	}
+	return cc
+}
+
+// SetValue sets the "value" field.
+func (cc *CompletionCreate) SetValue(f float64) *CompletionCreate {
+	cc.mutation.SetValue(f)
+	return cc
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cc *CompletionCreate) SetNillableValue(f *float64) *CompletionCreate {
+	if f != nil {
+		cc.SetValue(*f)
+	}
+	return cc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cc *CompletionCreate) SetCreatedAt(t time.Time) *CompletionCreate {
+	cc.mutation.SetCreatedAt(t)
+	return cc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cc *CompletionCreate) SetNillableCreatedAt(t *time.Time) *CompletionCreate {
+	if t != nil {
+		cc.SetCreatedAt(*t)
+	}
+	return cc
+}
+
+// SetHabit sets the "habit" edge to the Habit entity.
+func (cc *CompletionCreate) SetHabit(h *Habit) *CompletionCreate {
+	return cc.SetHabitID(h.ID)
+}
+
+// Mutation returns the CompletionMutation object of the builder.
+func (cc *CompletionCreate) Mutation() *CompletionMutation {
+	return cc.mutation
+}
+
+// Save creates the Completion in the database.
+func (cc *CompletionCreate) Save(ctx context.Context) (*Completion, error) {
+	cc.defaults()
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *CompletionCreate) SaveX(ctx context.Context) *Completion {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *CompletionCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *CompletionCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cc *CompletionCreate) defaults() {
+	if _, ok := cc.mutation.CompletedAt(); !ok {
+		v := completion.DefaultCompletedAt()
+		cc.mutation.SetCompletedAt(v)
+	}
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		v := completion.DefaultCreatedAt()
+		cc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *CompletionCreate) check() error {
+	if _, ok := cc.mutation.HabitID(); !ok {
+		return &ValidationError{Name: "habit_id", err: errors.New(`ent: missing required field "Completion.habit_id"`)}
+	}
+	if _, ok := cc.mutation.CompletedAt(); !ok {
+		return &ValidationError{Name: "completed_at", err: errors.New(`ent: missing required field "Completion.completed_at"`)}
+	}
+	if v, ok := cc.mutation.Value(); ok {
+		if err := completion.ValueValidator(v); err != nil {
+			return &ValidationError{Name: "value", err: fmt.Errorf(`ent: validator failed for field "Completion.value": %w`, err)}
+		}
+	}
+	if _, ok := cc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Completion.created_at"`)}
+	}
+	if len(cc.mutation.HabitIDs()) == 0 {
+		return &ValidationError{Name: "habit", err: errors.New(`ent: missing required edge "Completion.habit"`)}
+	}
+	return nil
+}
+
+func (cc *CompletionCreate) sqlSave(ctx context.Context) (*Completion, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *CompletionCreate) createSpec() (*Completion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Completion{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(completion.Table, sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt))
+	)
+	if value, ok := cc.mutation.CompletedAt(); ok {
+		_spec.SetField(completion.FieldCompletedAt, field.TypeTime, value)
+		_node.CompletedAt = value
+	}
+	if value, ok := cc.mutation.Note(); ok {
+		_spec.SetField(completion.FieldNote, field.TypeString, value)
+		_node.Note = value
+	}
+	if value, ok := cc.mutation.Value(); ok {
+		_spec.SetField(completion.FieldValue, field.TypeFloat64, value)
+		_node.Value = &value
+	}
+	if value, ok := cc.mutation.CreatedAt(); ok {
+		_spec.SetField(completion.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := cc.mutation.HabitIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   completion.HabitTable,
+			Columns: []string{completion.HabitColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(habit.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.HabitID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// CompletionCreateBulk is the builder for creating many Completion entities in bulk.
+type CompletionCreateBulk struct {
+	config
+	err      error
+	builders []*CompletionCreate
+}
+
+// Save creates the Completion entities in the database.
+func (ccb *CompletionCreateBulk) Save(ctx context.Context) ([]*Completion, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Completion, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CompletionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *CompletionCreateBulk) SaveX(ctx context.Context) []*Completion {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *CompletionCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *CompletionCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,491 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api/ent/completion"
+	"api/ent/habit"
+	"api/ent/predicate"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// CompletionUpdate is the builder for updating Completion entities.
+type CompletionUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *CompletionMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the CompletionUpdate builder.
+func (cu *CompletionUpdate) Where(ps ...predicate.Completion) *CompletionUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// SetHabitID sets the "habit_id" field.
+func (cu *CompletionUpdate) SetHabitID(i int) *CompletionUpdate {
+	cu.mutation.SetHabitID(i)
+	return cu
+}
+
+// SetNillableHabitID sets the "habit_id" field if the given value is not nil.
+func (cu *CompletionUpdate) SetNillableHabitID(i *int) *CompletionUpdate {
+	if i != nil {
+		cu.SetHabitID(*i)
+	}
+	return cu
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (cu *CompletionUpdate) SetCompletedAt(t time.Time) *CompletionUpdate {
+	cu.mutation.SetCompletedAt(t)
+	return cu
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (cu *CompletionUpdate) SetNillableCompletedAt(t *time.Time) *CompletionUpdate {
+	if t != nil {
+		cu.SetCompletedAt(*t)
+	}
+	return cu
+}
+
+// SetNote sets the "note" field.
+func (cu *CompletionUpdate) SetNote(s string) *CompletionUpdate {
+	cu.mutation.SetNote(s)
+	return cu
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (cu *CompletionUpdate) SetNillableNote(s *string) *CompletionUpdate {
+	if s != nil {
+		cu.SetNote(*s)
+	}
+	return cu
+}
+
+// ClearNote clears the value of the "note" field.
+func (cu *CompletionUpdate) ClearNote() *CompletionUpdate {
+	cu.mutation.ClearNote()
+	return cu
+}
+
+// SetValue sets the "value" field.
+func (cu *CompletionUpdate) SetValue(f float64) *CompletionUpdate {
+	cu.mutation.ResetValue()
+	cu.mutation.SetValue(f)
+	return cu
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cu *CompletionUpdate) SetNillableValue(f *float64) *CompletionUpdate {
+	if f != nil {
+		cu.SetValue(*f)
+	}
+	return cu
+}
+
+// AddValue adds f to the "value" field.
+func (cu *CompletionUpdate) AddValue(f float64) *CompletionUpdate {
+	cu.mutation.AddValue(f)
+	return cu
+}
+
+// ClearValue clears the value of the "value" field.
+func (cu *CompletionUpdate) ClearValue() *CompletionUpdate {
+	cu.mutation.ClearValue()
+	return cu
+}
+
+// SetHabit sets the "habit" edge to the Habit entity.
+func (cu *CompletionUpdate) SetHabit(h *Habit) *CompletionUpdate {
+	return cu.SetHabitID(h.ID)
+}
+
+// Mutation returns the CompletionMutation object of the builder.
+func (cu *CompletionUpdate) Mutation() *CompletionMutation {
+	return cu.mutation
+}
+
+// ClearHabit clears the "habit" edge to the Habit entity.
+func (cu *CompletionUpdate) ClearHabit() *CompletionUpdate {
+	cu.mutation.ClearHabit()
+	return cu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *CompletionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *CompletionUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *CompletionUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *CompletionUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cu *CompletionUpdate) check() error {
+	if v, ok := cu.mutation.Value(); ok {
+		if err := completion.ValueValidator(v); err != nil {
+			return &ValidationError{Name: "value", err: fmt.Errorf(`ent: validator failed for field "Completion.value": %w`, err)}
+		}
+	}
+	if cu.mutation.HabitCleared() && len(cu.mutation.HabitIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Completion.habit"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (cu *CompletionUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *CompletionUpdate {
+	cu.modifiers = append(cu.modifiers, modifiers...)
+	return cu
+}
+
+func (cu *CompletionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := cu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(completion.Table, completion.Columns, sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cu.mutation.CompletedAt(); ok {
+		_spec.SetField(completion.FieldCompletedAt, field.TypeTime, value)
+	}
+	if value, ok := cu.mutation.Note(); ok {
+		_spec.SetField(completion.FieldNote, field.TypeString, value)
+	}
+	if cu.mutation.NoteCleared() {
+		_spec.ClearField(completion.FieldNote, field.TypeString)
+	}
+	if value, ok := cu.mutation.Value(); ok {
+		_spec.SetField(completion.FieldValue, field.TypeFloat64, value)
+	}
+	if value, ok := cu.mutation.AddedValue(); ok {
+		_spec.AddField(completion.FieldValue, field.TypeFloat64, value)
+	}
+	if cu.mutation.ValueCleared() {
+		_spec.ClearField(completion.FieldValue, field.TypeFloat64)
+	}
+	if cu.mutation.HabitCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   completion.HabitTable,
+			Columns: []string{completion.HabitColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(habit.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cu.mutation.HabitIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   completion.HabitTable,
+			Columns: []string{completion.HabitColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(habit.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(cu.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{completion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// CompletionUpdateOne is the builder for updating a single Completion entity.
+type CompletionUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *CompletionMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetHabitID sets the "habit_id" field.
+func (cuo *CompletionUpdateOne) SetHabitID(i int) *CompletionUpdateOne {
+	cuo.mutation.SetHabitID(i)
+	return cuo
+}
+
+// SetNillableHabitID sets the "habit_id" field if the given value is not nil.
+func (cuo *CompletionUpdateOne) SetNillableHabitID(i *int) *CompletionUpdateOne {
+	if i != nil {
+		cuo.SetHabitID(*i)
+	}
+	return cuo
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (cuo *CompletionUpdateOne) SetCompletedAt(t time.Time) *CompletionUpdateOne {
+	cuo.mutation.SetCompletedAt(t)
+	return cuo
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (cuo *CompletionUpdateOne) SetNillableCompletedAt(t *time.Time) *CompletionUpdateOne {
+	if t != nil {
+		cuo.SetCompletedAt(*t)
+	}
+	return cuo
+}
+
+// SetNote sets the "note" field.
+func (cuo *CompletionUpdateOne) SetNote(s string) *CompletionUpdateOne {
+	cuo.mutation.SetNote(s)
+	return cuo
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (cuo *CompletionUpdateOne) SetNillableNote(s *string) *CompletionUpdateOne {
+	if s != nil {
+		cuo.SetNote(*s)
+	}
+	return cuo
+}
+
+// ClearNote clears the value of the "note" field.
+func (cuo *CompletionUpdateOne) ClearNote() *CompletionUpdateOne {
+	cuo.mutation.ClearNote()
+	return cuo
+}
+
+// SetValue sets the "value" field.
+func (cuo *CompletionUpdateOne) SetValue(f float64) *CompletionUpdateOne {
+	cuo.mutation.ResetValue()
+	cuo.mutation.SetValue(f)
+	return cuo
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cuo *CompletionUpdateOne) SetNillableValue(f *float64) *CompletionUpdateOne {
+	if f != nil {
+		cuo.SetValue(*f)
+	}
+	return cuo
+}
+
+// AddValue adds f to the "value" field.
+func (cuo *CompletionUpdateOne) AddValue(f float64) *CompletionUpdateOne {
+	cuo.mutation.AddValue(f)
+	return cuo
+}
+
+// ClearValue clears the value of the "value" field.
+func (cuo *CompletionUpdateOne) ClearValue() *CompletionUpdateOne {
+	cuo.mutation.ClearValue()
+	return cuo
+}
+
+// SetHabit sets the "habit" edge to the Habit entity.
+func (cuo *CompletionUpdateOne) SetHabit(h *Habit) *CompletionUpdateOne {
+	return cuo.SetHabitID(h.ID)
+}
+
+// Mutation returns the CompletionMutation object of the builder.
+func (cuo *CompletionUpdateOne) Mutation() *CompletionMutation {
+	return cuo.mutation
+}
+
+// ClearHabit clears the "habit" edge to the Habit entity.
+func (cuo *CompletionUpdateOne) ClearHabit() *CompletionUpdateOne {
+	cuo.mutation.ClearHabit()
+	return cuo
+}
+
+// Where appends a list predicates to the CompletionUpdate builder.
+func (cuo *CompletionUpdateOne) Where(ps ...predicate.Completion) *CompletionUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *CompletionUpdateOne) Select(field string, fields ...string) *CompletionUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Completion entity.
+func (cuo *CompletionUpdateOne) Save(ctx context.Context) (*Completion, error) {
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *CompletionUpdateOne) SaveX(ctx context.Context) *Completion {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *CompletionUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *CompletionUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cuo *CompletionUpdateOne) check() error {
+	if v, ok := cuo.mutation.Value(); ok {
+		if err := completion.ValueValidator(v); err != nil {
+			return &ValidationError{Name: "value", err: fmt.Errorf(`ent: validator failed for field "Completion.value": %w`, err)}
+		}
+	}
+	if cuo.mutation.HabitCleared() && len(cuo.mutation.HabitIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Completion.habit"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (cuo *CompletionUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *CompletionUpdateOne {
+	cuo.modifiers = append(cuo.modifiers, modifiers...)
+	return cuo
+}
+
+func (cuo *CompletionUpdateOne) sqlSave(ctx context.Context) (_node *Completion, err error) {
+	if err := cuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(completion.Table, completion.Columns, sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Completion.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, completion.FieldID)
+		for _, f := range fields {
+			if !completion.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != completion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cuo.mutation.CompletedAt(); ok {
+		_spec.SetField(completion.FieldCompletedAt, field.TypeTime, value)
+	}
+	if value, ok := cuo.mutation.Note(); ok {
+		_spec.SetField(completion.FieldNote, field.TypeString, value)
+	}
+	if cuo.mutation.NoteCleared() {
+		_spec.ClearField(completion.FieldNote, field.TypeString)
+	}
+	if value, ok := cuo.mutation.Value(); ok {
+		_spec.SetField(completion.FieldValue, field.TypeFloat64, value)
+	}
+	if value, ok := cuo.mutation.AddedValue(); ok {
+		_spec.AddField(completion.FieldValue, field.TypeFloat64, value)
+	}
+	if cuo.mutation.ValueCleared() {
+		_spec.ClearField(completion.FieldValue, field.TypeFloat64)
+	}
+	if cuo.mutation.HabitCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   completion.HabitTable,
+			Columns: []string{completion.HabitColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(habit.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cuo.mutation.HabitIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   completion.HabitTable,
+			Columns: []string{completion.HabitColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(habit.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(cuo.modifiers...)
+	_node = &Completion{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{completion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
@@ -3,7 +3,13 @@
 package ent
 
 import (
+	"api/ent/apitoken"
+	"api/ent/auditevent"
+	"api/ent/completion"
 	"api/ent/habit"
+	"api/ent/idempotencykey"
+	"api/ent/session"
+	"api/ent/user"
 	"context"
 	"errors"
 	"fmt"
@@ -73,7 +79,13 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			habit.Table: habit.ValidColumn,
+			apitoken.Table:       apitoken.ValidColumn,
+			auditevent.Table:     auditevent.ValidColumn,
+			completion.Table:     completion.ValidColumn,
+			habit.Table:          habit.ValidColumn,
+			idempotencykey.Table: idempotencykey.ValidColumn,
+			session.Table:        session.ValidColumn,
+			user.Table:           user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
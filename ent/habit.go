@@ -4,6 +4,8 @@ package ent
 
 import (
 	"api/ent/habit"
+	"api/ent/user"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -19,23 +21,99 @@ type Habit struct {
 	ID int `json:"id,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
+	// NameKey holds the value of the "name_key" field.
+	NameKey string `json:"-"`
 	// Description holds the value of the "description" field.
 	Description string `json:"description,omitempty"`
+	// Color holds the value of the "color" field.
+	Color string `json:"color,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
-	CreatedAt    time.Time `json:"created_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// OwnerID holds the value of the "owner_id" field.
+	OwnerID int `json:"owner_id,omitempty"`
+	// Schedule holds the value of the "schedule" field.
+	Schedule habit.Schedule `json:"schedule,omitempty"`
+	// Weekdays holds the value of the "weekdays" field.
+	Weekdays []int `json:"weekdays,omitempty"`
+	// TimesPerPeriod holds the value of the "times_per_period" field.
+	TimesPerPeriod int `json:"times_per_period,omitempty"`
+	// IntervalDays holds the value of the "interval_days" field.
+	IntervalDays int `json:"interval_days,omitempty"`
+	// Rrule holds the value of the "rrule" field.
+	Rrule string `json:"rrule,omitempty"`
+	// Polarity holds the value of the "polarity" field.
+	Polarity habit.Polarity `json:"polarity,omitempty"`
+	// Type holds the value of the "type" field.
+	Type habit.Type `json:"type,omitempty"`
+	// Unit holds the value of the "unit" field.
+	Unit string `json:"unit,omitempty"`
+	// Target holds the value of the "target" field.
+	Target *float64 `json:"target,omitempty"`
+	// TargetDirection holds the value of the "target_direction" field.
+	TargetDirection habit.TargetDirection `json:"target_direction,omitempty"`
+	// Tags holds the value of the "tags" field.
+	Tags []string `json:"tags,omitempty"`
+	// Archived holds the value of the "archived" field.
+	Archived bool `json:"archived,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the HabitQuery when eager-loading is set.
+	Edges        HabitEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// HabitEdges holds the relations/edges for other nodes in the graph.
+type HabitEdges struct {
+	// Owner holds the value of the owner edge.
+	Owner *User `json:"owner,omitempty"`
+	// Completions holds the value of the completions edge.
+	Completions []*Completion `json:"completions,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HabitEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// CompletionsOrErr returns the Completions value or an error if the edge
+// was not loaded in eager-loading.
+func (e HabitEdges) CompletionsOrErr() ([]*Completion, error) {
+	if e.loadedTypes[1] {
+		return e.Completions, nil
+	}
+	return nil, &NotLoadedError{edge: "completions"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Habit) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case habit.FieldID:
+		case habit.FieldWeekdays, habit.FieldTags:
+			values[i] = new([]byte)
+		case habit.FieldArchived:
+			values[i] = new(sql.NullBool)
+		case habit.FieldTarget:
+			values[i] = new(sql.NullFloat64)
+		case habit.FieldID, habit.FieldOwnerID, habit.FieldTimesPerPeriod, habit.FieldIntervalDays, habit.FieldVersion:
 			values[i] = new(sql.NullInt64)
-		case habit.FieldName, habit.FieldDescription:
+		case habit.FieldName, habit.FieldNameKey, habit.FieldDescription, habit.FieldColor, habit.FieldSchedule, habit.FieldRrule, habit.FieldPolarity, habit.FieldType, habit.FieldUnit, habit.FieldTargetDirection:
 			values[i] = new(sql.NullString)
-		case habit.FieldCreatedAt:
+		case habit.FieldCreatedAt, habit.FieldUpdatedAt, habit.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -64,18 +142,132 @@ func (h *Habit) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				h.Name = value.String
 			}
+		case habit.FieldNameKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name_key", values[i])
+			} else if value.Valid {
+				h.NameKey = value.String
+			}
 		case habit.FieldDescription:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field description", values[i])
 			} else if value.Valid {
 				h.Description = value.String
 			}
+		case habit.FieldColor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field color", values[i])
+			} else if value.Valid {
+				h.Color = value.String
+			}
 		case habit.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
 			} else if value.Valid {
 				h.CreatedAt = value.Time
 			}
+		case habit.FieldOwnerID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field owner_id", values[i])
+			} else if value.Valid {
+				h.OwnerID = int(value.Int64)
+			}
+		case habit.FieldSchedule:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field schedule", values[i])
+			} else if value.Valid {
+				h.Schedule = habit.Schedule(value.String)
+			}
+		case habit.FieldWeekdays:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field weekdays", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &h.Weekdays); err != nil {
+					return fmt.Errorf("unmarshal field weekdays: %w", err)
+				}
+			}
+		case habit.FieldTimesPerPeriod:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field times_per_period", values[i])
+			} else if value.Valid {
+				h.TimesPerPeriod = int(value.Int64)
+			}
+		case habit.FieldIntervalDays:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field interval_days", values[i])
+			} else if value.Valid {
+				h.IntervalDays = int(value.Int64)
+			}
+		case habit.FieldRrule:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field rrule", values[i])
+			} else if value.Valid {
+				h.Rrule = value.String
+			}
+		case habit.FieldPolarity:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field polarity", values[i])
+			} else if value.Valid {
+				h.Polarity = habit.Polarity(value.String)
+			}
+		case habit.FieldType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field type", values[i])
+			} else if value.Valid {
+				h.Type = habit.Type(value.String)
+			}
+		case habit.FieldUnit:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field unit", values[i])
+			} else if value.Valid {
+				h.Unit = value.String
+			}
+		case habit.FieldTarget:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field target", values[i])
+			} else if value.Valid {
+				h.Target = new(float64)
+				*h.Target = value.Float64
+			}
+		case habit.FieldTargetDirection:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target_direction", values[i])
+			} else if value.Valid {
+				h.TargetDirection = habit.TargetDirection(value.String)
+			}
+		case habit.FieldTags:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field tags", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &h.Tags); err != nil {
+					return fmt.Errorf("unmarshal field tags: %w", err)
+				}
+			}
+		case habit.FieldArchived:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field archived", values[i])
+			} else if value.Valid {
+				h.Archived = value.Bool
+			}
+		case habit.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				h.Version = int(value.Int64)
+			}
+		case habit.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				h.UpdatedAt = value.Time
+			}
+		case habit.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				h.DeletedAt = new(time.Time)
+				*h.DeletedAt = value.Time
+			}
 		default:
 			h.selectValues.Set(columns[i], values[i])
 		}
@@ -89,6 +281,16 @@ func (h *Habit) Value(name string) (ent.Value, error) {
 	return h.selectValues.Get(name)
 }
 
+// QueryOwner queries the "owner" edge of the Habit entity.
+func (h *Habit) QueryOwner() *UserQuery {
+	return NewHabitClient(h.config).QueryOwner(h)
+}
+
+// QueryCompletions queries the "completions" edge of the Habit entity.
+func (h *Habit) QueryCompletions() *CompletionQuery {
+	return NewHabitClient(h.config).QueryCompletions(h)
+}
+
 // Update returns a builder for updating this Habit.
 // Note that you need to call Habit.Unwrap() before calling this method if this Habit
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -115,11 +317,69 @@ func (h *Habit) String() string {
 	builder.WriteString("name=")
 	builder.WriteString(h.Name)
 	builder.WriteString(", ")
+	builder.WriteString("name_key=")
+	builder.WriteString(h.NameKey)
+	builder.WriteString(", ")
 	builder.WriteString("description=")
 	builder.WriteString(h.Description)
 	builder.WriteString(", ")
+	builder.WriteString("color=")
+	builder.WriteString(h.Color)
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(h.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("owner_id=")
+	builder.WriteString(fmt.Sprintf("%v", h.OwnerID))
+	builder.WriteString(", ")
+	builder.WriteString("schedule=")
+	builder.WriteString(fmt.Sprintf("%v", h.Schedule))
+	builder.WriteString(", ")
+	builder.WriteString("weekdays=")
+	builder.WriteString(fmt.Sprintf("%v", h.Weekdays))
+	builder.WriteString(", ")
+	builder.WriteString("times_per_period=")
+	builder.WriteString(fmt.Sprintf("%v", h.TimesPerPeriod))
+	builder.WriteString(", ")
+	builder.WriteString("interval_days=")
+	builder.WriteString(fmt.Sprintf("%v", h.IntervalDays))
+	builder.WriteString(", ")
+	builder.WriteString("rrule=")
+	builder.WriteString(h.Rrule)
+	builder.WriteString(", ")
+	builder.WriteString("polarity=")
+	builder.WriteString(fmt.Sprintf("%v", h.Polarity))
+	builder.WriteString(", ")
+	builder.WriteString("type=")
+	builder.WriteString(fmt.Sprintf("%v", h.Type))
+	builder.WriteString(", ")
+	builder.WriteString("unit=")
+	builder.WriteString(h.Unit)
+	builder.WriteString(", ")
+	if v := h.Target; v != nil {
+		builder.WriteString("target=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("target_direction=")
+	builder.WriteString(fmt.Sprintf("%v", h.TargetDirection))
+	builder.WriteString(", ")
+	builder.WriteString("tags=")
+	builder.WriteString(fmt.Sprintf("%v", h.Tags))
+	builder.WriteString(", ")
+	builder.WriteString("archived=")
+	builder.WriteString(fmt.Sprintf("%v", h.Archived))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", h.Version))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(h.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := h.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -3,9 +3,12 @@
 package habit
 
 import (
+	"fmt"
 	"time"
 
+	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
 )
 
 const (
@@ -15,20 +18,92 @@ const (
 	FieldID = "id"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
+	// FieldNameKey holds the string denoting the name_key field in the database.
+	FieldNameKey = "name_key"
 	// FieldDescription holds the string denoting the description field in the database.
 	FieldDescription = "description"
+	// FieldColor holds the string denoting the color field in the database.
+	FieldColor = "color"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldOwnerID holds the string denoting the owner_id field in the database.
+	FieldOwnerID = "owner_id"
+	// FieldSchedule holds the string denoting the schedule field in the database.
+	FieldSchedule = "schedule"
+	// FieldWeekdays holds the string denoting the weekdays field in the database.
+	FieldWeekdays = "weekdays"
+	// FieldTimesPerPeriod holds the string denoting the times_per_period field in the database.
+	FieldTimesPerPeriod = "times_per_period"
+	// FieldIntervalDays holds the string denoting the interval_days field in the database.
+	FieldIntervalDays = "interval_days"
+	// FieldRrule holds the string denoting the rrule field in the database.
+	FieldRrule = "rrule"
+	// FieldPolarity holds the string denoting the polarity field in the database.
+	FieldPolarity = "polarity"
+	// FieldType holds the string denoting the type field in the database.
+	FieldType = "type"
+	// FieldUnit holds the string denoting the unit field in the database.
+	FieldUnit = "unit"
+	// FieldTarget holds the string denoting the target field in the database.
+	FieldTarget = "target"
+	// FieldTargetDirection holds the string denoting the target_direction field in the database.
+	FieldTargetDirection = "target_direction"
+	// FieldTags holds the string denoting the tags field in the database.
+	FieldTags = "tags"
+	// FieldArchived holds the string denoting the archived field in the database.
+	FieldArchived = "archived"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
+	FieldDeletedAt = "deleted_at"
+	// EdgeOwner holds the string denoting the owner edge name in mutations.
+	EdgeOwner = "owner"
+	// EdgeCompletions holds the string denoting the completions edge name in mutations.
+	EdgeCompletions = "completions"
 	// Table holds the table name of the habit in the database.
 	Table = "habits"
+	// OwnerTable is the table that holds the owner relation/edge.
+	OwnerTable = "habits"
+	// OwnerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerInverseTable = "users"
+	// OwnerColumn is the table column denoting the owner relation/edge.
+	OwnerColumn = "owner_id"
+	// CompletionsTable is the table that holds the completions relation/edge.
+	CompletionsTable = "completions"
+	// CompletionsInverseTable is the table name for the Completion entity.
+	// It exists in this package in order to avoid circular dependency with the "completion" package.
+	CompletionsInverseTable = "completions"
+	// CompletionsColumn is the table column denoting the completions relation/edge.
+	CompletionsColumn = "habit_id"
 )
 
 // Columns holds all SQL columns for habit fields.
 var Columns = []string{
 	FieldID,
 	FieldName,
+	FieldNameKey,
 	FieldDescription,
+	FieldColor,
 	FieldCreatedAt,
+	FieldOwnerID,
+	FieldSchedule,
+	FieldWeekdays,
+	FieldTimesPerPeriod,
+	FieldIntervalDays,
+	FieldRrule,
+	FieldPolarity,
+	FieldType,
+	FieldUnit,
+	FieldTarget,
+	FieldTargetDirection,
+	FieldTags,
+	FieldArchived,
+	FieldVersion,
+	FieldUpdatedAt,
+	FieldDeletedAt,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -41,13 +116,142 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "api/ent/runtime"
 var (
+	Hooks        [3]ent.Hook
+	Interceptors [1]ent.Interceptor
 	// NameValidator is a validator for the "name" field. It is called by the builders before save.
 	NameValidator func(string) error
+	// ColorValidator is a validator for the "color" field. It is called by the builders before save.
+	ColorValidator func(string) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// TimesPerPeriodValidator is a validator for the "times_per_period" field. It is called by the builders before save.
+	TimesPerPeriodValidator func(int) error
+	// IntervalDaysValidator is a validator for the "interval_days" field. It is called by the builders before save.
+	IntervalDaysValidator func(int) error
+	// TargetValidator is a validator for the "target" field. It is called by the builders before save.
+	TargetValidator func(float64) error
+	// DefaultArchived holds the default value on creation for the "archived" field.
+	DefaultArchived bool
+	// DefaultVersion holds the default value on creation for the "version" field.
+	DefaultVersion int
+	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
+	DefaultUpdatedAt func() time.Time
+	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
+	UpdateDefaultUpdatedAt func() time.Time
 )
 
+// Schedule defines the type for the "schedule" enum field.
+type Schedule string
+
+// ScheduleDaily is the default value of the Schedule enum.
+const DefaultSchedule = ScheduleDaily
+
+// Schedule values.
+const (
+	ScheduleDaily    Schedule = "daily"
+	ScheduleWeekdays Schedule = "weekdays"
+	ScheduleWeekly   Schedule = "weekly"
+	ScheduleMonthly  Schedule = "monthly"
+	ScheduleInterval Schedule = "interval"
+	ScheduleRrule    Schedule = "rrule"
+)
+
+func (s Schedule) String() string {
+	return string(s)
+}
+
+// ScheduleValidator is a validator for the "schedule" field enum values. It is called by the builders before save.
+func ScheduleValidator(s Schedule) error {
+	switch s {
+	case ScheduleDaily, ScheduleWeekdays, ScheduleWeekly, ScheduleMonthly, ScheduleInterval, ScheduleRrule:
+		return nil
+	default:
+		return fmt.Errorf("habit: invalid enum value for schedule field: %q", s)
+	}
+}
+
+// Polarity defines the type for the "polarity" enum field.
+type Polarity string
+
+// PolarityBuild is the default value of the Polarity enum.
+const DefaultPolarity = PolarityBuild
+
+// Polarity values.
+const (
+	PolarityBuild Polarity = "build"
+	PolarityQuit  Polarity = "quit"
+)
+
+func (po Polarity) String() string {
+	return string(po)
+}
+
+// PolarityValidator is a validator for the "polarity" field enum values. It is called by the builders before save.
+func PolarityValidator(po Polarity) error {
+	switch po {
+	case PolarityBuild, PolarityQuit:
+		return nil
+	default:
+		return fmt.Errorf("habit: invalid enum value for polarity field: %q", po)
+	}
+}
+
+// Type defines the type for the "type" enum field.
+type Type string
+
+// TypeBoolean is the default value of the Type enum.
+const DefaultType = TypeBoolean
+
+// Type values.
+const (
+	TypeBoolean  Type = "boolean"
+	TypeNumeric  Type = "numeric"
+	TypeDuration Type = "duration"
+)
+
+func (_type Type) String() string {
+	return string(_type)
+}
+
+// TypeValidator is a validator for the "type" field enum values. It is called by the builders before save.
+func TypeValidator(_type Type) error {
+	switch _type {
+	case TypeBoolean, TypeNumeric, TypeDuration:
+		return nil
+	default:
+		return fmt.Errorf("habit: invalid enum value for type field: %q", _type)
+	}
+}
+
+// TargetDirection defines the type for the "target_direction" enum field.
+type TargetDirection string
+
+// TargetDirection values.
+const (
+	TargetDirectionAtLeast TargetDirection = "at_least"
+	TargetDirectionAtMost  TargetDirection = "at_most"
+)
+
+func (td TargetDirection) String() string {
+	return string(td)
+}
+
+// TargetDirectionValidator is a validator for the "target_direction" field enum values. It is called by the builders before save.
+func TargetDirectionValidator(td TargetDirection) error {
+	switch td {
+	case TargetDirectionAtLeast, TargetDirectionAtMost:
+		return nil
+	default:
+		return fmt.Errorf("habit: invalid enum value for target_direction field: %q", td)
+	}
+}
+
 // OrderOption defines the ordering options for the Habit queries.
 type OrderOption func(*sql.Selector)
 
@@ -61,12 +265,127 @@ func ByName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldName, opts...).ToFunc()
 }
 
+// ByNameKey orders the results by the name_key field.
+func ByNameKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNameKey, opts...).ToFunc()
+}
+
 // ByDescription orders the results by the description field.
 func ByDescription(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldDescription, opts...).ToFunc()
 }
 
+// ByColor orders the results by the color field.
+func ByColor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldColor, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
+
+// ByOwnerID orders the results by the owner_id field.
+func ByOwnerID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOwnerID, opts...).ToFunc()
+}
+
+// BySchedule orders the results by the schedule field.
+func BySchedule(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSchedule, opts...).ToFunc()
+}
+
+// ByTimesPerPeriod orders the results by the times_per_period field.
+func ByTimesPerPeriod(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTimesPerPeriod, opts...).ToFunc()
+}
+
+// ByIntervalDays orders the results by the interval_days field.
+func ByIntervalDays(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIntervalDays, opts...).ToFunc()
+}
+
+// ByRrule orders the results by the rrule field.
+func ByRrule(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRrule, opts...).ToFunc()
+}
+
+// ByPolarity orders the results by the polarity field.
+func ByPolarity(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPolarity, opts...).ToFunc()
+}
+
+// ByType orders the results by the type field.
+func ByType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldType, opts...).ToFunc()
+}
+
+// ByUnit orders the results by the unit field.
+func ByUnit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUnit, opts...).ToFunc()
+}
+
+// ByTarget orders the results by the target field.
+func ByTarget(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTarget, opts...).ToFunc()
+}
+
+// ByTargetDirection orders the results by the target_direction field.
+func ByTargetDirection(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTargetDirection, opts...).ToFunc()
+}
+
+// ByArchived orders the results by the archived field.
+func ByArchived(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldArchived, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
+
+// ByDeletedAt orders the results by the deleted_at field.
+func ByDeletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletedAt, opts...).ToFunc()
+}
+
+// ByOwnerField orders the results by owner field.
+func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnerStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByCompletionsCount orders the results by completions count.
+func ByCompletionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newCompletionsStep(), opts...)
+	}
+}
+
+// ByCompletions orders the results by completions terms.
+func ByCompletions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newCompletionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newOwnerStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OwnerInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+	)
+}
+func newCompletionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(CompletionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, CompletionsTable, CompletionsColumn),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
 )
 
 // ID filters vertices based on their ID field.
@@ -59,16 +60,76 @@ func Name(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldName, v))
 }
 
+// NameKey applies equality check predicate on the "name_key" field. It's identical to NameKeyEQ.
+func NameKey(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldNameKey, v))
+}
+
 // Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
 func Description(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldDescription, v))
 }
 
+// Color applies equality check predicate on the "color" field. It's identical to ColorEQ.
+func Color(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldColor, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// OwnerID applies equality check predicate on the "owner_id" field. It's identical to OwnerIDEQ.
+func OwnerID(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// TimesPerPeriod applies equality check predicate on the "times_per_period" field. It's identical to TimesPerPeriodEQ.
+func TimesPerPeriod(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldTimesPerPeriod, v))
+}
+
+// IntervalDays applies equality check predicate on the "interval_days" field. It's identical to IntervalDaysEQ.
+func IntervalDays(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldIntervalDays, v))
+}
+
+// Rrule applies equality check predicate on the "rrule" field. It's identical to RruleEQ.
+func Rrule(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldRrule, v))
+}
+
+// Unit applies equality check predicate on the "unit" field. It's identical to UnitEQ.
+func Unit(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldUnit, v))
+}
+
+// Target applies equality check predicate on the "target" field. It's identical to TargetEQ.
+func Target(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldTarget, v))
+}
+
+// Archived applies equality check predicate on the "archived" field. It's identical to ArchivedEQ.
+func Archived(v bool) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldArchived, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldVersion, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// DeletedAt applies equality check predicate on the "deleted_at" field. It's identical to DeletedAtEQ.
+func DeletedAt(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldDeletedAt, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldName, v))
@@ -134,6 +195,81 @@ func NameContainsFold(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldContainsFold(FieldName, v))
 }
 
+// NameKeyEQ applies the EQ predicate on the "name_key" field.
+func NameKeyEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldNameKey, v))
+}
+
+// NameKeyNEQ applies the NEQ predicate on the "name_key" field.
+func NameKeyNEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldNameKey, v))
+}
+
+// NameKeyIn applies the In predicate on the "name_key" field.
+func NameKeyIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldNameKey, vs...))
+}
+
+// NameKeyNotIn applies the NotIn predicate on the "name_key" field.
+func NameKeyNotIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldNameKey, vs...))
+}
+
+// NameKeyGT applies the GT predicate on the "name_key" field.
+func NameKeyGT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldNameKey, v))
+}
+
+// NameKeyGTE applies the GTE predicate on the "name_key" field.
+func NameKeyGTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldNameKey, v))
+}
+
+// NameKeyLT applies the LT predicate on the "name_key" field.
+func NameKeyLT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldNameKey, v))
+}
+
+// NameKeyLTE applies the LTE predicate on the "name_key" field.
+func NameKeyLTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldNameKey, v))
+}
+
+// NameKeyContains applies the Contains predicate on the "name_key" field.
+func NameKeyContains(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContains(FieldNameKey, v))
+}
+
+// NameKeyHasPrefix applies the HasPrefix predicate on the "name_key" field.
+func NameKeyHasPrefix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasPrefix(FieldNameKey, v))
+}
+
+// NameKeyHasSuffix applies the HasSuffix predicate on the "name_key" field.
+func NameKeyHasSuffix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasSuffix(FieldNameKey, v))
+}
+
+// NameKeyIsNil applies the IsNil predicate on the "name_key" field.
+func NameKeyIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldNameKey))
+}
+
+// NameKeyNotNil applies the NotNil predicate on the "name_key" field.
+func NameKeyNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldNameKey))
+}
+
+// NameKeyEqualFold applies the EqualFold predicate on the "name_key" field.
+func NameKeyEqualFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEqualFold(FieldNameKey, v))
+}
+
+// NameKeyContainsFold applies the ContainsFold predicate on the "name_key" field.
+func NameKeyContainsFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContainsFold(FieldNameKey, v))
+}
+
 // DescriptionEQ applies the EQ predicate on the "description" field.
 func DescriptionEQ(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldDescription, v))
@@ -209,6 +345,81 @@ func DescriptionContainsFold(v string) predicate.Habit {
 	return predicate.Habit(sql.FieldContainsFold(FieldDescription, v))
 }
 
+// ColorEQ applies the EQ predicate on the "color" field.
+func ColorEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldColor, v))
+}
+
+// ColorNEQ applies the NEQ predicate on the "color" field.
+func ColorNEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldColor, v))
+}
+
+// ColorIn applies the In predicate on the "color" field.
+func ColorIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldColor, vs...))
+}
+
+// ColorNotIn applies the NotIn predicate on the "color" field.
+func ColorNotIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldColor, vs...))
+}
+
+// ColorGT applies the GT predicate on the "color" field.
+func ColorGT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldColor, v))
+}
+
+// ColorGTE applies the GTE predicate on the "color" field.
+func ColorGTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldColor, v))
+}
+
+// ColorLT applies the LT predicate on the "color" field.
+func ColorLT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldColor, v))
+}
+
+// ColorLTE applies the LTE predicate on the "color" field.
+func ColorLTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldColor, v))
+}
+
+// ColorContains applies the Contains predicate on the "color" field.
+func ColorContains(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContains(FieldColor, v))
+}
+
+// ColorHasPrefix applies the HasPrefix predicate on the "color" field.
+func ColorHasPrefix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasPrefix(FieldColor, v))
+}
+
+// ColorHasSuffix applies the HasSuffix predicate on the "color" field.
+func ColorHasSuffix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasSuffix(FieldColor, v))
+}
+
+// ColorIsNil applies the IsNil predicate on the "color" field.
+func ColorIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldColor))
+}
+
+// ColorNotNil applies the NotNil predicate on the "color" field.
+func ColorNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldColor))
+}
+
+// ColorEqualFold applies the EqualFold predicate on the "color" field.
+func ColorEqualFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEqualFold(FieldColor, v))
+}
+
+// ColorContainsFold applies the ContainsFold predicate on the "color" field.
+func ColorContainsFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContainsFold(FieldColor, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Habit {
 	return predicate.Habit(sql.FieldEQ(FieldCreatedAt, v))
@@ -249,6 +460,632 @@ func CreatedAtLTE(v time.Time) predicate.Habit {
 	return predicate.Habit(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// OwnerIDEQ applies the EQ predicate on the "owner_id" field.
+func OwnerIDEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// OwnerIDNEQ applies the NEQ predicate on the "owner_id" field.
+func OwnerIDNEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldOwnerID, v))
+}
+
+// OwnerIDIn applies the In predicate on the "owner_id" field.
+func OwnerIDIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDNotIn applies the NotIn predicate on the "owner_id" field.
+func OwnerIDNotIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldOwnerID, vs...))
+}
+
+// ScheduleEQ applies the EQ predicate on the "schedule" field.
+func ScheduleEQ(v Schedule) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldSchedule, v))
+}
+
+// ScheduleNEQ applies the NEQ predicate on the "schedule" field.
+func ScheduleNEQ(v Schedule) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldSchedule, v))
+}
+
+// ScheduleIn applies the In predicate on the "schedule" field.
+func ScheduleIn(vs ...Schedule) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldSchedule, vs...))
+}
+
+// ScheduleNotIn applies the NotIn predicate on the "schedule" field.
+func ScheduleNotIn(vs ...Schedule) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldSchedule, vs...))
+}
+
+// WeekdaysIsNil applies the IsNil predicate on the "weekdays" field.
+func WeekdaysIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldWeekdays))
+}
+
+// WeekdaysNotNil applies the NotNil predicate on the "weekdays" field.
+func WeekdaysNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldWeekdays))
+}
+
+// TimesPerPeriodEQ applies the EQ predicate on the "times_per_period" field.
+func TimesPerPeriodEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodNEQ applies the NEQ predicate on the "times_per_period" field.
+func TimesPerPeriodNEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodIn applies the In predicate on the "times_per_period" field.
+func TimesPerPeriodIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldTimesPerPeriod, vs...))
+}
+
+// TimesPerPeriodNotIn applies the NotIn predicate on the "times_per_period" field.
+func TimesPerPeriodNotIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldTimesPerPeriod, vs...))
+}
+
+// TimesPerPeriodGT applies the GT predicate on the "times_per_period" field.
+func TimesPerPeriodGT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodGTE applies the GTE predicate on the "times_per_period" field.
+func TimesPerPeriodGTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodLT applies the LT predicate on the "times_per_period" field.
+func TimesPerPeriodLT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodLTE applies the LTE predicate on the "times_per_period" field.
+func TimesPerPeriodLTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldTimesPerPeriod, v))
+}
+
+// TimesPerPeriodIsNil applies the IsNil predicate on the "times_per_period" field.
+func TimesPerPeriodIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldTimesPerPeriod))
+}
+
+// TimesPerPeriodNotNil applies the NotNil predicate on the "times_per_period" field.
+func TimesPerPeriodNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldTimesPerPeriod))
+}
+
+// IntervalDaysEQ applies the EQ predicate on the "interval_days" field.
+func IntervalDaysEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldIntervalDays, v))
+}
+
+// IntervalDaysNEQ applies the NEQ predicate on the "interval_days" field.
+func IntervalDaysNEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldIntervalDays, v))
+}
+
+// IntervalDaysIn applies the In predicate on the "interval_days" field.
+func IntervalDaysIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldIntervalDays, vs...))
+}
+
+// IntervalDaysNotIn applies the NotIn predicate on the "interval_days" field.
+func IntervalDaysNotIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldIntervalDays, vs...))
+}
+
+// IntervalDaysGT applies the GT predicate on the "interval_days" field.
+func IntervalDaysGT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldIntervalDays, v))
+}
+
+// IntervalDaysGTE applies the GTE predicate on the "interval_days" field.
+func IntervalDaysGTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldIntervalDays, v))
+}
+
+// IntervalDaysLT applies the LT predicate on the "interval_days" field.
+func IntervalDaysLT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldIntervalDays, v))
+}
+
+// IntervalDaysLTE applies the LTE predicate on the "interval_days" field.
+func IntervalDaysLTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldIntervalDays, v))
+}
+
+// IntervalDaysIsNil applies the IsNil predicate on the "interval_days" field.
+func IntervalDaysIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldIntervalDays))
+}
+
+// IntervalDaysNotNil applies the NotNil predicate on the "interval_days" field.
+func IntervalDaysNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldIntervalDays))
+}
+
+// RruleEQ applies the EQ predicate on the "rrule" field.
+func RruleEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldRrule, v))
+}
+
+// RruleNEQ applies the NEQ predicate on the "rrule" field.
+func RruleNEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldRrule, v))
+}
+
+// RruleIn applies the In predicate on the "rrule" field.
+func RruleIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldRrule, vs...))
+}
+
+// RruleNotIn applies the NotIn predicate on the "rrule" field.
+func RruleNotIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldRrule, vs...))
+}
+
+// RruleGT applies the GT predicate on the "rrule" field.
+func RruleGT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldRrule, v))
+}
+
+// RruleGTE applies the GTE predicate on the "rrule" field.
+func RruleGTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldRrule, v))
+}
+
+// RruleLT applies the LT predicate on the "rrule" field.
+func RruleLT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldRrule, v))
+}
+
+// RruleLTE applies the LTE predicate on the "rrule" field.
+func RruleLTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldRrule, v))
+}
+
+// RruleContains applies the Contains predicate on the "rrule" field.
+func RruleContains(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContains(FieldRrule, v))
+}
+
+// RruleHasPrefix applies the HasPrefix predicate on the "rrule" field.
+func RruleHasPrefix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasPrefix(FieldRrule, v))
+}
+
+// RruleHasSuffix applies the HasSuffix predicate on the "rrule" field.
+func RruleHasSuffix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasSuffix(FieldRrule, v))
+}
+
+// RruleIsNil applies the IsNil predicate on the "rrule" field.
+func RruleIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldRrule))
+}
+
+// RruleNotNil applies the NotNil predicate on the "rrule" field.
+func RruleNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldRrule))
+}
+
+// RruleEqualFold applies the EqualFold predicate on the "rrule" field.
+func RruleEqualFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEqualFold(FieldRrule, v))
+}
+
+// RruleContainsFold applies the ContainsFold predicate on the "rrule" field.
+func RruleContainsFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContainsFold(FieldRrule, v))
+}
+
+// PolarityEQ applies the EQ predicate on the "polarity" field.
+func PolarityEQ(v Polarity) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldPolarity, v))
+}
+
+// PolarityNEQ applies the NEQ predicate on the "polarity" field.
+func PolarityNEQ(v Polarity) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldPolarity, v))
+}
+
+// PolarityIn applies the In predicate on the "polarity" field.
+func PolarityIn(vs ...Polarity) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldPolarity, vs...))
+}
+
+// PolarityNotIn applies the NotIn predicate on the "polarity" field.
+func PolarityNotIn(vs ...Polarity) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldPolarity, vs...))
+}
+
+// TypeEQ applies the EQ predicate on the "type" field.
+func TypeEQ(v Type) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldType, v))
+}
+
+// TypeNEQ applies the NEQ predicate on the "type" field.
+func TypeNEQ(v Type) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldType, v))
+}
+
+// TypeIn applies the In predicate on the "type" field.
+func TypeIn(vs ...Type) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldType, vs...))
+}
+
+// TypeNotIn applies the NotIn predicate on the "type" field.
+func TypeNotIn(vs ...Type) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldType, vs...))
+}
+
+// UnitEQ applies the EQ predicate on the "unit" field.
+func UnitEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldUnit, v))
+}
+
+// UnitNEQ applies the NEQ predicate on the "unit" field.
+func UnitNEQ(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldUnit, v))
+}
+
+// UnitIn applies the In predicate on the "unit" field.
+func UnitIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldUnit, vs...))
+}
+
+// UnitNotIn applies the NotIn predicate on the "unit" field.
+func UnitNotIn(vs ...string) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldUnit, vs...))
+}
+
+// UnitGT applies the GT predicate on the "unit" field.
+func UnitGT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldUnit, v))
+}
+
+// UnitGTE applies the GTE predicate on the "unit" field.
+func UnitGTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldUnit, v))
+}
+
+// UnitLT applies the LT predicate on the "unit" field.
+func UnitLT(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldUnit, v))
+}
+
+// UnitLTE applies the LTE predicate on the "unit" field.
+func UnitLTE(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldUnit, v))
+}
+
+// UnitContains applies the Contains predicate on the "unit" field.
+func UnitContains(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContains(FieldUnit, v))
+}
+
+// UnitHasPrefix applies the HasPrefix predicate on the "unit" field.
+func UnitHasPrefix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasPrefix(FieldUnit, v))
+}
+
+// UnitHasSuffix applies the HasSuffix predicate on the "unit" field.
+func UnitHasSuffix(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldHasSuffix(FieldUnit, v))
+}
+
+// UnitIsNil applies the IsNil predicate on the "unit" field.
+func UnitIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldUnit))
+}
+
+// UnitNotNil applies the NotNil predicate on the "unit" field.
+func UnitNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldUnit))
+}
+
+// UnitEqualFold applies the EqualFold predicate on the "unit" field.
+func UnitEqualFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldEqualFold(FieldUnit, v))
+}
+
+// UnitContainsFold applies the ContainsFold predicate on the "unit" field.
+func UnitContainsFold(v string) predicate.Habit {
+	return predicate.Habit(sql.FieldContainsFold(FieldUnit, v))
+}
+
+// TargetEQ applies the EQ predicate on the "target" field.
+func TargetEQ(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldTarget, v))
+}
+
+// TargetNEQ applies the NEQ predicate on the "target" field.
+func TargetNEQ(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldTarget, v))
+}
+
+// TargetIn applies the In predicate on the "target" field.
+func TargetIn(vs ...float64) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldTarget, vs...))
+}
+
+// TargetNotIn applies the NotIn predicate on the "target" field.
+func TargetNotIn(vs ...float64) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldTarget, vs...))
+}
+
+// TargetGT applies the GT predicate on the "target" field.
+func TargetGT(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldTarget, v))
+}
+
+// TargetGTE applies the GTE predicate on the "target" field.
+func TargetGTE(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldTarget, v))
+}
+
+// TargetLT applies the LT predicate on the "target" field.
+func TargetLT(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldTarget, v))
+}
+
+// TargetLTE applies the LTE predicate on the "target" field.
+func TargetLTE(v float64) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldTarget, v))
+}
+
+// TargetIsNil applies the IsNil predicate on the "target" field.
+func TargetIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldTarget))
+}
+
+// TargetNotNil applies the NotNil predicate on the "target" field.
+func TargetNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldTarget))
+}
+
+// TargetDirectionEQ applies the EQ predicate on the "target_direction" field.
+func TargetDirectionEQ(v TargetDirection) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldTargetDirection, v))
+}
+
+// TargetDirectionNEQ applies the NEQ predicate on the "target_direction" field.
+func TargetDirectionNEQ(v TargetDirection) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldTargetDirection, v))
+}
+
+// TargetDirectionIn applies the In predicate on the "target_direction" field.
+func TargetDirectionIn(vs ...TargetDirection) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldTargetDirection, vs...))
+}
+
+// TargetDirectionNotIn applies the NotIn predicate on the "target_direction" field.
+func TargetDirectionNotIn(vs ...TargetDirection) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldTargetDirection, vs...))
+}
+
+// TargetDirectionIsNil applies the IsNil predicate on the "target_direction" field.
+func TargetDirectionIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldTargetDirection))
+}
+
+// TargetDirectionNotNil applies the NotNil predicate on the "target_direction" field.
+func TargetDirectionNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldTargetDirection))
+}
+
+// TagsIsNil applies the IsNil predicate on the "tags" field.
+func TagsIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldTags))
+}
+
+// TagsNotNil applies the NotNil predicate on the "tags" field.
+func TagsNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldTags))
+}
+
+// ArchivedEQ applies the EQ predicate on the "archived" field.
+func ArchivedEQ(v bool) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldArchived, v))
+}
+
+// ArchivedNEQ applies the NEQ predicate on the "archived" field.
+func ArchivedNEQ(v bool) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldArchived, v))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldVersion, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIsNil applies the IsNil predicate on the "updated_at" field.
+func UpdatedAtIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldUpdatedAt))
+}
+
+// UpdatedAtNotNil applies the NotNil predicate on the "updated_at" field.
+func UpdatedAtNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldUpdatedAt))
+}
+
+// DeletedAtEQ applies the EQ predicate on the "deleted_at" field.
+func DeletedAtEQ(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtNEQ applies the NEQ predicate on the "deleted_at" field.
+func DeletedAtNEQ(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldNEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtIn applies the In predicate on the "deleted_at" field.
+func DeletedAtIn(vs ...time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtNotIn applies the NotIn predicate on the "deleted_at" field.
+func DeletedAtNotIn(vs ...time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldNotIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtGT applies the GT predicate on the "deleted_at" field.
+func DeletedAtGT(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldGT(FieldDeletedAt, v))
+}
+
+// DeletedAtGTE applies the GTE predicate on the "deleted_at" field.
+func DeletedAtGTE(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldGTE(FieldDeletedAt, v))
+}
+
+// DeletedAtLT applies the LT predicate on the "deleted_at" field.
+func DeletedAtLT(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldLT(FieldDeletedAt, v))
+}
+
+// DeletedAtLTE applies the LTE predicate on the "deleted_at" field.
+func DeletedAtLTE(v time.Time) predicate.Habit {
+	return predicate.Habit(sql.FieldLTE(FieldDeletedAt, v))
+}
+
+// DeletedAtIsNil applies the IsNil predicate on the "deleted_at" field.
+func DeletedAtIsNil() predicate.Habit {
+	return predicate.Habit(sql.FieldIsNull(FieldDeletedAt))
+}
+
+// DeletedAtNotNil applies the NotNil predicate on the "deleted_at" field.
+func DeletedAtNotNil() predicate.Habit {
+	return predicate.Habit(sql.FieldNotNull(FieldDeletedAt))
+}
+
+// HasOwner applies the HasEdge predicate on the "owner" edge.
+func HasOwner() predicate.Habit {
+	return predicate.Habit(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOwnerWith applies the HasEdge predicate on the "owner" edge with a given conditions (other predicates).
+func HasOwnerWith(preds ...predicate.User) predicate.Habit {
+	return predicate.Habit(func(s *sql.Selector) {
+		step := newOwnerStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasCompletions applies the HasEdge predicate on the "completions" edge.
+func HasCompletions() predicate.Habit {
+	return predicate.Habit(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, CompletionsTable, CompletionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasCompletionsWith applies the HasEdge predicate on the "completions" edge with a given conditions (other predicates).
+func HasCompletionsWith(preds ...predicate.Completion) predicate.Habit {
+	return predicate.Habit(func(s *sql.Selector) {
+		step := newCompletionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Habit) predicate.Habit {
 	return predicate.Habit(sql.AndPredicates(predicates...))
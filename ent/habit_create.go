@@ -3,7 +3,9 @@
 package ent
 
 import (
+	"api/ent/completion"
 	"api/ent/habit"
+	"api/ent/user"
 	"context"
 	"errors"
 	"fmt"
@@ -26,6 +28,20 @@ func (hc *HabitCreate) SetName(s string) *HabitCreate {
 	return hc
 }
 
+// SetNameKey sets the "name_key" field.
+func (hc *HabitCreate) SetNameKey(s string) *HabitCreate {
+	hc.mutation.SetNameKey(s)
+	return hc
+}
+
+// SetNillableNameKey sets the "name_key" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableNameKey(s *string) *HabitCreate {
+	if s != nil {
+		hc.SetNameKey(*s)
+	}
+	return hc
+}
+
 // SetDescription sets the "description" field.
 func (hc *HabitCreate) SetDescription(s string) *HabitCreate {
 	hc.mutation.SetDescription(s)
@@ -40,6 +56,20 @@ func (hc *HabitCreate) SetNillableDescription(s *string) *HabitCreate {
 	return hc
 }
 
+// SetColor sets the "color" field.
+func (hc *HabitCreate) SetColor(s string) *HabitCreate {
+	hc.mutation.SetColor(s)
+	return hc
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableColor(s *string) *HabitCreate {
+	if s != nil {
+		hc.SetColor(*s)
+	}
+	return hc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (hc *HabitCreate) SetCreatedAt(t time.Time) *HabitCreate {
 	hc.mutation.SetCreatedAt(t)
@@ -54,6 +84,226 @@ func (hc *HabitCreate) SetNillableCreatedAt(t *time.Time) *HabitCreate {
 	return hc
 }
 
+// SetOwnerID sets the "owner_id" field.
+func (hc *HabitCreate) SetOwnerID(i int) *HabitCreate {
+	hc.mutation.SetOwnerID(i)
+	return hc
+}
+
+// SetSchedule sets the "schedule" field.
+func (hc *HabitCreate) SetSchedule(h habit.Schedule) *HabitCreate {
+	hc.mutation.SetSchedule(h)
+	return hc
+}
+
+// SetNillableSchedule sets the "schedule" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableSchedule(h *habit.Schedule) *HabitCreate {
+	if h != nil {
+		hc.SetSchedule(*h)
+	}
+	return hc
+}
+
+// SetWeekdays sets the "weekdays" field.
+func (hc *HabitCreate) SetWeekdays(i []int) *HabitCreate {
+	hc.mutation.SetWeekdays(i)
+	return hc
+}
+
+// SetTimesPerPeriod sets the "times_per_period" field.
+func (hc *HabitCreate) SetTimesPerPeriod(i int) *HabitCreate {
+	hc.mutation.SetTimesPerPeriod(i)
+	return hc
+}
+
+// SetNillableTimesPerPeriod sets the "times_per_period" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableTimesPerPeriod(i *int) *HabitCreate {
+	if i != nil {
+		hc.SetTimesPerPeriod(*i)
+	}
+	return hc
+}
+
+// SetIntervalDays sets the "interval_days" field.
+func (hc *HabitCreate) SetIntervalDays(i int) *HabitCreate {
+	hc.mutation.SetIntervalDays(i)
+	return hc
+}
+
+// SetNillableIntervalDays sets the "interval_days" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableIntervalDays(i *int) *HabitCreate {
+	if i != nil {
+		hc.SetIntervalDays(*i)
+	}
+	return hc
+}
+
+// SetRrule sets the "rrule" field.
+func (hc *HabitCreate) SetRrule(s string) *HabitCreate {
+	hc.mutation.SetRrule(s)
+	return hc
+}
+
+// SetNillableRrule sets the "rrule" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableRrule(s *string) *HabitCreate {
+	if s != nil {
+		hc.SetRrule(*s)
+	}
+	return hc
+}
+
+// SetPolarity sets the "polarity" field.
+func (hc *HabitCreate) SetPolarity(h habit.Polarity) *HabitCreate {
+	hc.mutation.SetPolarity(h)
+	return hc
+}
+
+// SetNillablePolarity sets the "polarity" field if the given value is not nil.
+func (hc *HabitCreate) SetNillablePolarity(h *habit.Polarity) *HabitCreate {
+	if h != nil {
+		hc.SetPolarity(*h)
+	}
+	return hc
+}
+
+// SetType sets the "type" field.
+func (hc *HabitCreate) SetType(h habit.Type) *HabitCreate {
+	hc.mutation.SetType(h)
+	return hc
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableType(h *habit.Type) *HabitCreate {
+	if h != nil {
+		hc.SetType(*h)
+	}
+	return hc
+}
+
+// SetUnit sets the "unit" field.
+func (hc *HabitCreate) SetUnit(s string) *HabitCreate {
+	hc.mutation.SetUnit(s)
+	return hc
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableUnit(s *string) *HabitCreate {
+	if s != nil {
+		hc.SetUnit(*s)
+	}
+	return hc
+}
+
+// SetTarget sets the "target" field.
+func (hc *HabitCreate) SetTarget(f float64) *HabitCreate {
+	hc.mutation.SetTarget(f)
+	return hc
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableTarget(f *float64) *HabitCreate {
+	if f != nil {
+		hc.SetTarget(*f)
+	}
+	return hc
+}
+
+// SetTargetDirection sets the "target_direction" field.
+func (hc *HabitCreate) SetTargetDirection(hd habit.TargetDirection) *HabitCreate {
+	hc.mutation.SetTargetDirection(hd)
+	return hc
+}
+
+// SetNillableTargetDirection sets the "target_direction" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableTargetDirection(hd *habit.TargetDirection) *HabitCreate {
+	if hd != nil {
+		hc.SetTargetDirection(*hd)
+	}
+	return hc
+}
+
+// SetTags sets the "tags" field.
+func (hc *HabitCreate) SetTags(s []string) *HabitCreate {
+	hc.mutation.SetTags(s)
+	return hc
+}
+
+// SetArchived sets the "archived" field.
+func (hc *HabitCreate) SetArchived(b bool) *HabitCreate {
+	hc.mutation.SetArchived(b)
+	return hc
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableArchived(b *bool) *HabitCreate {
+	if b != nil {
+		hc.SetArchived(*b)
+	}
+	return hc
+}
+
+// SetVersion sets the "version" field.
+func (hc *HabitCreate) SetVersion(i int) *HabitCreate {
+	hc.mutation.SetVersion(i)
+	return hc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableVersion(i *int) *HabitCreate {
+	if i != nil {
+		hc.SetVersion(*i)
+	}
+	return hc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (hc *HabitCreate) SetUpdatedAt(t time.Time) *HabitCreate {
+	hc.mutation.SetUpdatedAt(t)
+	return hc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableUpdatedAt(t *time.Time) *HabitCreate {
+	if t != nil {
+		hc.SetUpdatedAt(*t)
+	}
+	return hc
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (hc *HabitCreate) SetDeletedAt(t time.Time) *HabitCreate {
+	hc.mutation.SetDeletedAt(t)
+	return hc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (hc *HabitCreate) SetNillableDeletedAt(t *time.Time) *HabitCreate {
+	if t != nil {
+		hc.SetDeletedAt(*t)
+	}
+	return hc
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (hc *HabitCreate) SetOwner(u *User) *HabitCreate {
+	return hc.SetOwnerID(u.ID)
+}
+
+// AddCompletionIDs adds the "completions" edge to the Completion entity by IDs.
+func (hc *HabitCreate) AddCompletionIDs(ids ...int) *HabitCreate {
+	hc.mutation.AddCompletionIDs(ids...)
+	return hc
+}
+
+// AddCompletions adds the "completions" edges to the Completion entity.
+func (hc *HabitCreate) AddCompletions(c ...*Completion) *HabitCreate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return hc.AddCompletionIDs(ids...)
+}
+
 // Mutation returns the HabitMutation object of the builder.
 func (hc *HabitCreate) Mutation() *HabitMutation {
 	return hc.mutation
@@ -61,7 +311,9 @@ func (hc *HabitCreate) Mutation() *HabitMutation {
 
 // Save creates the Habit in the database.
 func (hc *HabitCreate) Save(ctx context.Context) (*Habit, error) {
-	hc.defaults()
+	if err := hc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, hc.sqlSave, hc.mutation, hc.hooks)
 }
 
@@ -88,11 +340,42 @@ func (hc *HabitCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (hc *HabitCreate) defaults() {
+func (hc *HabitCreate) defaults() error {
 	if _, ok := hc.mutation.CreatedAt(); !ok {
+		if habit.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized habit.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
 		v := habit.DefaultCreatedAt()
 		hc.mutation.SetCreatedAt(v)
 	}
+	if _, ok := hc.mutation.Schedule(); !ok {
+		v := habit.DefaultSchedule
+		hc.mutation.SetSchedule(v)
+	}
+	if _, ok := hc.mutation.Polarity(); !ok {
+		v := habit.DefaultPolarity
+		hc.mutation.SetPolarity(v)
+	}
+	if _, ok := hc.mutation.GetType(); !ok {
+		v := habit.DefaultType
+		hc.mutation.SetType(v)
+	}
+	if _, ok := hc.mutation.Archived(); !ok {
+		v := habit.DefaultArchived
+		hc.mutation.SetArchived(v)
+	}
+	if _, ok := hc.mutation.Version(); !ok {
+		v := habit.DefaultVersion
+		hc.mutation.SetVersion(v)
+	}
+	if _, ok := hc.mutation.UpdatedAt(); !ok {
+		if habit.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized habit.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := habit.DefaultUpdatedAt()
+		hc.mutation.SetUpdatedAt(v)
+	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -105,9 +388,70 @@ func (hc *HabitCreate) check() error {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Habit.name": %w`, err)}
 		}
 	}
+	if v, ok := hc.mutation.Color(); ok {
+		if err := habit.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Habit.color": %w`, err)}
+		}
+	}
 	if _, ok := hc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Habit.created_at"`)}
 	}
+	if _, ok := hc.mutation.OwnerID(); !ok {
+		return &ValidationError{Name: "owner_id", err: errors.New(`ent: missing required field "Habit.owner_id"`)}
+	}
+	if _, ok := hc.mutation.Schedule(); !ok {
+		return &ValidationError{Name: "schedule", err: errors.New(`ent: missing required field "Habit.schedule"`)}
+	}
+	if v, ok := hc.mutation.Schedule(); ok {
+		if err := habit.ScheduleValidator(v); err != nil {
+			return &ValidationError{Name: "schedule", err: fmt.Errorf(`ent: validator failed for field "Habit.schedule": %w`, err)}
+		}
+	}
+	if v, ok := hc.mutation.TimesPerPeriod(); ok {
+		if err := habit.TimesPerPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "times_per_period", err: fmt.Errorf(`ent: validator failed for field "Habit.times_per_period": %w`, err)}
+		}
+	}
+	if v, ok := hc.mutation.IntervalDays(); ok {
+		if err := habit.IntervalDaysValidator(v); err != nil {
+			return &ValidationError{Name: "interval_days", err: fmt.Errorf(`ent: validator failed for field "Habit.interval_days": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.Polarity(); !ok {
+		return &ValidationError{Name: "polarity", err: errors.New(`ent: missing required field "Habit.polarity"`)}
+	}
+	if v, ok := hc.mutation.Polarity(); ok {
+		if err := habit.PolarityValidator(v); err != nil {
+			return &ValidationError{Name: "polarity", err: fmt.Errorf(`ent: validator failed for field "Habit.polarity": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "Habit.type"`)}
+	}
+	if v, ok := hc.mutation.GetType(); ok {
+		if err := habit.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Habit.type": %w`, err)}
+		}
+	}
+	if v, ok := hc.mutation.Target(); ok {
+		if err := habit.TargetValidator(v); err != nil {
+			return &ValidationError{Name: "target", err: fmt.Errorf(`ent: validator failed for field "Habit.target": %w`, err)}
+		}
+	}
+	if v, ok := hc.mutation.TargetDirection(); ok {
+		if err := habit.TargetDirectionValidator(v); err != nil {
+			return &ValidationError{Name: "target_direction", err: fmt.Errorf(`ent: validator failed for field "Habit.target_direction": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.Archived(); !ok {
+		return &ValidationError{Name: "archived", err: errors.New(`ent: missing required field "Habit.archived"`)}
+	}
+	if _, ok := hc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Habit.version"`)}
+	}
+	if len(hc.mutation.OwnerIDs()) == 0 {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Habit.owner"`)}
+	}
 	return nil
 }
 
@@ -138,14 +482,115 @@ func (hc *HabitCreate) createSpec() (*Habit, *sqlgraph.CreateSpec) {
 		_spec.SetField(habit.FieldName, field.TypeString, value)
 		_node.Name = value
 	}
+	if value, ok := hc.mutation.NameKey(); ok {
+		_spec.SetField(habit.FieldNameKey, field.TypeString, value)
+		_node.NameKey = value
+	}
 	if value, ok := hc.mutation.Description(); ok {
 		_spec.SetField(habit.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
+	if value, ok := hc.mutation.Color(); ok {
+		_spec.SetField(habit.FieldColor, field.TypeString, value)
+		_node.Color = value
+	}
 	if value, ok := hc.mutation.CreatedAt(); ok {
 		_spec.SetField(habit.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := hc.mutation.Schedule(); ok {
+		_spec.SetField(habit.FieldSchedule, field.TypeEnum, value)
+		_node.Schedule = value
+	}
+	if value, ok := hc.mutation.Weekdays(); ok {
+		_spec.SetField(habit.FieldWeekdays, field.TypeJSON, value)
+		_node.Weekdays = value
+	}
+	if value, ok := hc.mutation.TimesPerPeriod(); ok {
+		_spec.SetField(habit.FieldTimesPerPeriod, field.TypeInt, value)
+		_node.TimesPerPeriod = value
+	}
+	if value, ok := hc.mutation.IntervalDays(); ok {
+		_spec.SetField(habit.FieldIntervalDays, field.TypeInt, value)
+		_node.IntervalDays = value
+	}
+	if value, ok := hc.mutation.Rrule(); ok {
+		_spec.SetField(habit.FieldRrule, field.TypeString, value)
+		_node.Rrule = value
+	}
+	if value, ok := hc.mutation.Polarity(); ok {
+		_spec.SetField(habit.FieldPolarity, field.TypeEnum, value)
+		_node.Polarity = value
+	}
+	if value, ok := hc.mutation.GetType(); ok {
+		_spec.SetField(habit.FieldType, field.TypeEnum, value)
+		_node.Type = value
+	}
+	if value, ok := hc.mutation.Unit(); ok {
+		_spec.SetField(habit.FieldUnit, field.TypeString, value)
+		_node.Unit = value
+	}
+	if value, ok := hc.mutation.Target(); ok {
+		_spec.SetField(habit.FieldTarget, field.TypeFloat64, value)
+		_node.Target = &value
+	}
+	if value, ok := hc.mutation.TargetDirection(); ok {
+		_spec.SetField(habit.FieldTargetDirection, field.TypeEnum, value)
+		_node.TargetDirection = value
+	}
+	if value, ok := hc.mutation.Tags(); ok {
+		_spec.SetField(habit.FieldTags, field.TypeJSON, value)
+		_node.Tags = value
+	}
+	if value, ok := hc.mutation.Archived(); ok {
+		_spec.SetField(habit.FieldArchived, field.TypeBool, value)
+		_node.Archived = value
+	}
+	if value, ok := hc.mutation.Version(); ok {
+		_spec.SetField(habit.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := hc.mutation.UpdatedAt(); ok {
+		_spec.SetField(habit.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := hc.mutation.DeletedAt(); ok {
+		_spec.SetField(habit.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if nodes := hc.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   habit.OwnerTable,
+			Columns: []string{habit.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OwnerID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := hc.mutation.CompletionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -3,9 +3,12 @@
 package ent
 
 import (
+	"api/ent/completion"
 	"api/ent/habit"
 	"api/ent/predicate"
+	"api/ent/user"
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"math"
 
@@ -18,10 +21,13 @@ import (
 // HabitQuery is the builder for querying Habit entities.
 type HabitQuery struct {
 	config
-	ctx        *QueryContext
-	order      []habit.OrderOption
-	inters     []Interceptor
-	predicates []predicate.Habit
+	ctx             *QueryContext
+	order           []habit.OrderOption
+	inters          []Interceptor
+	predicates      []predicate.Habit
+	withOwner       *UserQuery
+	withCompletions *CompletionQuery
+	modifiers       []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -58,6 +64,50 @@ func (hq *HabitQuery) Order(o ...habit.OrderOption) *HabitQuery {
 	return hq
 }
 
+// QueryOwner chains the current query on the "owner" edge.
+func (hq *HabitQuery) QueryOwner() *UserQuery {
+	query := (&UserClient{config: hq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := hq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := hq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(habit.Table, habit.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, habit.OwnerTable, habit.OwnerColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(hq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryCompletions chains the current query on the "completions" edge.
+func (hq *HabitQuery) QueryCompletions() *CompletionQuery {
+	query := (&CompletionClient{config: hq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := hq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := hq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(habit.Table, habit.FieldID, selector),
+			sqlgraph.To(completion.Table, completion.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, habit.CompletionsTable, habit.CompletionsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(hq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Habit entity from the query.
 // Returns a *NotFoundError when no Habit was found.
 func (hq *HabitQuery) First(ctx context.Context) (*Habit, error) {
@@ -245,17 +295,42 @@ func (hq *HabitQuery) Clone() *HabitQuery {
 		return nil
 	}
 	return &HabitQuery{
-		config:     hq.config,
-		ctx:        hq.ctx.Clone(),
-		order:      append([]habit.OrderOption{}, hq.order...),
-		inters:     append([]Interceptor{}, hq.inters...),
-		predicates: append([]predicate.Habit{}, hq.predicates...),
+		config:          hq.config,
+		ctx:             hq.ctx.Clone(),
+		order:           append([]habit.OrderOption{}, hq.order...),
+		inters:          append([]Interceptor{}, hq.inters...),
+		predicates:      append([]predicate.Habit{}, hq.predicates...),
+		withOwner:       hq.withOwner.Clone(),
+		withCompletions: hq.withCompletions.Clone(),
 		// clone intermediate query.
-		sql:  hq.sql.Clone(),
-		path: hq.path,
+		sql:       hq.sql.Clone(),
+		path:      hq.path,
+		modifiers: append([]func(*sql.Selector){}, hq.modifiers...),
 	}
 }
 
+// WithOwner tells the query-builder to eager-load the nodes that are connected to
+// the "owner" edge. The optional arguments are used to configure the query builder of the edge.
+func (hq *HabitQuery) WithOwner(opts ...func(*UserQuery)) *HabitQuery {
+	query := (&UserClient{config: hq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	hq.withOwner = query
+	return hq
+}
+
+// WithCompletions tells the query-builder to eager-load the nodes that are connected to
+// the "completions" edge. The optional arguments are used to configure the query builder of the edge.
+func (hq *HabitQuery) WithCompletions(opts ...func(*CompletionQuery)) *HabitQuery {
+	query := (&CompletionClient{config: hq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	hq.withCompletions = query
+	return hq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -332,8 +407,12 @@ func (hq *HabitQuery) prepareQuery(ctx context.Context) error {
 
 func (hq *HabitQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Habit, error) {
 	var (
-		nodes = []*Habit{}
-		_spec = hq.querySpec()
+		nodes       = []*Habit{}
+		_spec       = hq.querySpec()
+		loadedTypes = [2]bool{
+			hq.withOwner != nil,
+			hq.withCompletions != nil,
+		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
 		return (*Habit).scanValues(nil, columns)
@@ -341,8 +420,12 @@ func (hq *HabitQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Habit,
 	_spec.Assign = func(columns []string, values []any) error {
 		node := &Habit{config: hq.config}
 		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(hq.modifiers) > 0 {
+		_spec.Modifiers = hq.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -352,11 +435,87 @@ func (hq *HabitQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Habit,
 	if len(nodes) == 0 {
 		return nodes, nil
 	}
+	if query := hq.withOwner; query != nil {
+		if err := hq.loadOwner(ctx, query, nodes, nil,
+			func(n *Habit, e *User) { n.Edges.Owner = e }); err != nil {
+			return nil, err
+		}
+	}
+	if query := hq.withCompletions; query != nil {
+		if err := hq.loadCompletions(ctx, query, nodes,
+			func(n *Habit) { n.Edges.Completions = []*Completion{} },
+			func(n *Habit, e *Completion) { n.Edges.Completions = append(n.Edges.Completions, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
+func (hq *HabitQuery) loadOwner(ctx context.Context, query *UserQuery, nodes []*Habit, init func(*Habit), assign func(*Habit, *User)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Habit)
+	for i := range nodes {
+		fk := nodes[i].OwnerID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "owner_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+func (hq *HabitQuery) loadCompletions(ctx context.Context, query *CompletionQuery, nodes []*Habit, init func(*Habit), assign func(*Habit, *Completion)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Habit)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(completion.FieldHabitID)
+	}
+	query.Where(predicate.Completion(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(habit.CompletionsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.HabitID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "habit_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+
 func (hq *HabitQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := hq.querySpec()
+	if len(hq.modifiers) > 0 {
+		_spec.Modifiers = hq.modifiers
+	}
 	_spec.Node.Columns = hq.ctx.Fields
 	if len(hq.ctx.Fields) > 0 {
 		_spec.Unique = hq.ctx.Unique != nil && *hq.ctx.Unique
@@ -380,6 +539,9 @@ func (hq *HabitQuery) querySpec() *sqlgraph.QuerySpec {
 				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
 			}
 		}
+		if hq.withOwner != nil {
+			_spec.Node.AddColumnOnce(habit.FieldOwnerID)
+		}
 	}
 	if ps := hq.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -419,6 +581,9 @@ func (hq *HabitQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if hq.ctx.Unique != nil && *hq.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range hq.modifiers {
+		m(selector)
+	}
 	for _, p := range hq.predicates {
 		p(selector)
 	}
@@ -436,6 +601,12 @@ func (hq *HabitQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// Modify adds a query modifier for attaching custom logic to queries.
+func (hq *HabitQuery) Modify(modifiers ...func(s *sql.Selector)) *HabitSelect {
+	hq.modifiers = append(hq.modifiers, modifiers...)
+	return hq.Select()
+}
+
 // HabitGroupBy is the group-by builder for Habit entities.
 type HabitGroupBy struct {
 	selector
@@ -525,3 +696,9 @@ func (hs *HabitSelect) sqlScan(ctx context.Context, root *HabitQuery, v any) err
 	defer rows.Close()
 	return sql.ScanSlice(rows, v)
 }
+
+// Modify adds a query modifier for attaching custom logic to queries.
+func (hs *HabitSelect) Modify(modifiers ...func(s *sql.Selector)) *HabitSelect {
+	hs.modifiers = append(hs.modifiers, modifiers...)
+	return hs
+}
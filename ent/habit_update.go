@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"api/ent/completion"
 	"api/ent/habit"
 	"api/ent/predicate"
 	"context"
@@ -12,14 +13,16 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 )
 
 // HabitUpdate is the builder for updating Habit entities.
 type HabitUpdate struct {
 	config
-	hooks    []Hook
-	mutation *HabitMutation
+	hooks     []Hook
+	mutation  *HabitMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // Where appends a list predicates to the HabitUpdate builder.
@@ -42,6 +45,26 @@ func (hu *HabitUpdate) SetNillableName(s *string) *HabitUpdate {
 	return hu
 }
 
+// SetNameKey sets the "name_key" field.
+func (hu *HabitUpdate) SetNameKey(s string) *HabitUpdate {
+	hu.mutation.SetNameKey(s)
+	return hu
+}
+
+// SetNillableNameKey sets the "name_key" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableNameKey(s *string) *HabitUpdate {
+	if s != nil {
+		hu.SetNameKey(*s)
+	}
+	return hu
+}
+
+// ClearNameKey clears the value of the "name_key" field.
+func (hu *HabitUpdate) ClearNameKey() *HabitUpdate {
+	hu.mutation.ClearNameKey()
+	return hu
+}
+
 // SetDescription sets the "description" field.
 func (hu *HabitUpdate) SetDescription(s string) *HabitUpdate {
 	hu.mutation.SetDescription(s)
@@ -62,6 +85,26 @@ func (hu *HabitUpdate) ClearDescription() *HabitUpdate {
 	return hu
 }
 
+// SetColor sets the "color" field.
+func (hu *HabitUpdate) SetColor(s string) *HabitUpdate {
+	hu.mutation.SetColor(s)
+	return hu
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableColor(s *string) *HabitUpdate {
+	if s != nil {
+		hu.SetColor(*s)
+	}
+	return hu
+}
+
+// ClearColor clears the value of the "color" field.
+func (hu *HabitUpdate) ClearColor() *HabitUpdate {
+	hu.mutation.ClearColor()
+	return hu
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (hu *HabitUpdate) SetCreatedAt(t time.Time) *HabitUpdate {
 	hu.mutation.SetCreatedAt(t)
@@ -76,13 +119,338 @@ func (hu *HabitUpdate) SetNillableCreatedAt(t *time.Time) *HabitUpdate {
 	return hu
 }
 
+// SetSchedule sets the "schedule" field.
+func (hu *HabitUpdate) SetSchedule(h habit.Schedule) *HabitUpdate {
+	hu.mutation.SetSchedule(h)
+	return hu
+}
+
+// SetNillableSchedule sets the "schedule" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableSchedule(h *habit.Schedule) *HabitUpdate {
+	if h != nil {
+		hu.SetSchedule(*h)
+	}
+	return hu
+}
+
+// SetWeekdays sets the "weekdays" field.
+func (hu *HabitUpdate) SetWeekdays(i []int) *HabitUpdate {
+	hu.mutation.SetWeekdays(i)
+	return hu
+}
+
+// AppendWeekdays appends i to the "weekdays" field.
+func (hu *HabitUpdate) AppendWeekdays(i []int) *HabitUpdate {
+	hu.mutation.AppendWeekdays(i)
+	return hu
+}
+
+// ClearWeekdays clears the value of the "weekdays" field.
+func (hu *HabitUpdate) ClearWeekdays() *HabitUpdate {
+	hu.mutation.ClearWeekdays()
+	return hu
+}
+
+// SetTimesPerPeriod sets the "times_per_period" field.
+func (hu *HabitUpdate) SetTimesPerPeriod(i int) *HabitUpdate {
+	hu.mutation.ResetTimesPerPeriod()
+	hu.mutation.SetTimesPerPeriod(i)
+	return hu
+}
+
+// SetNillableTimesPerPeriod sets the "times_per_period" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableTimesPerPeriod(i *int) *HabitUpdate {
+	if i != nil {
+		hu.SetTimesPerPeriod(*i)
+	}
+	return hu
+}
+
+// AddTimesPerPeriod adds i to the "times_per_period" field.
+func (hu *HabitUpdate) AddTimesPerPeriod(i int) *HabitUpdate {
+	hu.mutation.AddTimesPerPeriod(i)
+	return hu
+}
+
+// ClearTimesPerPeriod clears the value of the "times_per_period" field.
+func (hu *HabitUpdate) ClearTimesPerPeriod() *HabitUpdate {
+	hu.mutation.ClearTimesPerPeriod()
+	return hu
+}
+
+// SetIntervalDays sets the "interval_days" field.
+func (hu *HabitUpdate) SetIntervalDays(i int) *HabitUpdate {
+	hu.mutation.ResetIntervalDays()
+	hu.mutation.SetIntervalDays(i)
+	return hu
+}
+
+// SetNillableIntervalDays sets the "interval_days" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableIntervalDays(i *int) *HabitUpdate {
+	if i != nil {
+		hu.SetIntervalDays(*i)
+	}
+	return hu
+}
+
+// AddIntervalDays adds i to the "interval_days" field.
+func (hu *HabitUpdate) AddIntervalDays(i int) *HabitUpdate {
+	hu.mutation.AddIntervalDays(i)
+	return hu
+}
+
+// ClearIntervalDays clears the value of the "interval_days" field.
+func (hu *HabitUpdate) ClearIntervalDays() *HabitUpdate {
+	hu.mutation.ClearIntervalDays()
+	return hu
+}
+
+// SetRrule sets the "rrule" field.
+func (hu *HabitUpdate) SetRrule(s string) *HabitUpdate {
+	hu.mutation.SetRrule(s)
+	return hu
+}
+
+// SetNillableRrule sets the "rrule" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableRrule(s *string) *HabitUpdate {
+	if s != nil {
+		hu.SetRrule(*s)
+	}
+	return hu
+}
+
+// ClearRrule clears the value of the "rrule" field.
+func (hu *HabitUpdate) ClearRrule() *HabitUpdate {
+	hu.mutation.ClearRrule()
+	return hu
+}
+
+// SetPolarity sets the "polarity" field.
+func (hu *HabitUpdate) SetPolarity(h habit.Polarity) *HabitUpdate {
+	hu.mutation.SetPolarity(h)
+	return hu
+}
+
+// SetNillablePolarity sets the "polarity" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillablePolarity(h *habit.Polarity) *HabitUpdate {
+	if h != nil {
+		hu.SetPolarity(*h)
+	}
+	return hu
+}
+
+// SetType sets the "type" field.
+func (hu *HabitUpdate) SetType(h habit.Type) *HabitUpdate {
+	hu.mutation.SetType(h)
+	return hu
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableType(h *habit.Type) *HabitUpdate {
+	if h != nil {
+		hu.SetType(*h)
+	}
+	return hu
+}
+
+// SetUnit sets the "unit" field.
+func (hu *HabitUpdate) SetUnit(s string) *HabitUpdate {
+	hu.mutation.SetUnit(s)
+	return hu
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableUnit(s *string) *HabitUpdate {
+	if s != nil {
+		hu.SetUnit(*s)
+	}
+	return hu
+}
+
+// ClearUnit clears the value of the "unit" field.
+func (hu *HabitUpdate) ClearUnit() *HabitUpdate {
+	hu.mutation.ClearUnit()
+	return hu
+}
+
+// SetTarget sets the "target" field.
+func (hu *HabitUpdate) SetTarget(f float64) *HabitUpdate {
+	hu.mutation.ResetTarget()
+	hu.mutation.SetTarget(f)
+	return hu
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableTarget(f *float64) *HabitUpdate {
+	if f != nil {
+		hu.SetTarget(*f)
+	}
+	return hu
+}
+
+// AddTarget adds f to the "target" field.
+func (hu *HabitUpdate) AddTarget(f float64) *HabitUpdate {
+	hu.mutation.AddTarget(f)
+	return hu
+}
+
+// ClearTarget clears the value of the "target" field.
+func (hu *HabitUpdate) ClearTarget() *HabitUpdate {
+	hu.mutation.ClearTarget()
+	return hu
+}
+
+// SetTargetDirection sets the "target_direction" field.
+func (hu *HabitUpdate) SetTargetDirection(hd habit.TargetDirection) *HabitUpdate {
+	hu.mutation.SetTargetDirection(hd)
+	return hu
+}
+
+// SetNillableTargetDirection sets the "target_direction" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableTargetDirection(hd *habit.TargetDirection) *HabitUpdate {
+	if hd != nil {
+		hu.SetTargetDirection(*hd)
+	}
+	return hu
+}
+
+// ClearTargetDirection clears the value of the "target_direction" field.
+func (hu *HabitUpdate) ClearTargetDirection() *HabitUpdate {
+	hu.mutation.ClearTargetDirection()
+	return hu
+}
+
+// SetTags sets the "tags" field.
+func (hu *HabitUpdate) SetTags(s []string) *HabitUpdate {
+	hu.mutation.SetTags(s)
+	return hu
+}
+
+// AppendTags appends s to the "tags" field.
+func (hu *HabitUpdate) AppendTags(s []string) *HabitUpdate {
+	hu.mutation.AppendTags(s)
+	return hu
+}
+
+// ClearTags clears the value of the "tags" field.
+func (hu *HabitUpdate) ClearTags() *HabitUpdate {
+	hu.mutation.ClearTags()
+	return hu
+}
+
+// SetArchived sets the "archived" field.
+func (hu *HabitUpdate) SetArchived(b bool) *HabitUpdate {
+	hu.mutation.SetArchived(b)
+	return hu
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableArchived(b *bool) *HabitUpdate {
+	if b != nil {
+		hu.SetArchived(*b)
+	}
+	return hu
+}
+
+// SetVersion sets the "version" field.
+func (hu *HabitUpdate) SetVersion(i int) *HabitUpdate {
+	hu.mutation.ResetVersion()
+	hu.mutation.SetVersion(i)
+	return hu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableVersion(i *int) *HabitUpdate {
+	if i != nil {
+		hu.SetVersion(*i)
+	}
+	return hu
+}
+
+// AddVersion adds i to the "version" field.
+func (hu *HabitUpdate) AddVersion(i int) *HabitUpdate {
+	hu.mutation.AddVersion(i)
+	return hu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (hu *HabitUpdate) SetUpdatedAt(t time.Time) *HabitUpdate {
+	hu.mutation.SetUpdatedAt(t)
+	return hu
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (hu *HabitUpdate) ClearUpdatedAt() *HabitUpdate {
+	hu.mutation.ClearUpdatedAt()
+	return hu
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (hu *HabitUpdate) SetDeletedAt(t time.Time) *HabitUpdate {
+	hu.mutation.SetDeletedAt(t)
+	return hu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (hu *HabitUpdate) SetNillableDeletedAt(t *time.Time) *HabitUpdate {
+	if t != nil {
+		hu.SetDeletedAt(*t)
+	}
+	return hu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (hu *HabitUpdate) ClearDeletedAt() *HabitUpdate {
+	hu.mutation.ClearDeletedAt()
+	return hu
+}
+
+// AddCompletionIDs adds the "completions" edge to the Completion entity by IDs.
+func (hu *HabitUpdate) AddCompletionIDs(ids ...int) *HabitUpdate {
+	hu.mutation.AddCompletionIDs(ids...)
+	return hu
+}
+
+// AddCompletions adds the "completions" edges to the Completion entity.
+func (hu *HabitUpdate) AddCompletions(c ...*Completion) *HabitUpdate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return hu.AddCompletionIDs(ids...)
+}
+
 // Mutation returns the HabitMutation object of the builder.
 func (hu *HabitUpdate) Mutation() *HabitMutation {
 	return hu.mutation
 }
 
+// ClearCompletions clears all "completions" edges to the Completion entity.
+func (hu *HabitUpdate) ClearCompletions() *HabitUpdate {
+	hu.mutation.ClearCompletions()
+	return hu
+}
+
+// RemoveCompletionIDs removes the "completions" edge to Completion entities by IDs.
+func (hu *HabitUpdate) RemoveCompletionIDs(ids ...int) *HabitUpdate {
+	hu.mutation.RemoveCompletionIDs(ids...)
+	return hu
+}
+
+// RemoveCompletions removes "completions" edges to Completion entities.
+func (hu *HabitUpdate) RemoveCompletions(c ...*Completion) *HabitUpdate {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return hu.RemoveCompletionIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (hu *HabitUpdate) Save(ctx context.Context) (int, error) {
+	if err := hu.defaults(); err != nil {
+		return 0, err
+	}
 	return withHooks(ctx, hu.sqlSave, hu.mutation, hu.hooks)
 }
 
@@ -108,6 +476,18 @@ func (hu *HabitUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (hu *HabitUpdate) defaults() error {
+	if _, ok := hu.mutation.UpdatedAt(); !ok && !hu.mutation.UpdatedAtCleared() {
+		if habit.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized habit.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := habit.UpdateDefaultUpdatedAt()
+		hu.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (hu *HabitUpdate) check() error {
 	if v, ok := hu.mutation.Name(); ok {
@@ -115,9 +495,58 @@ func (hu *HabitUpdate) check() error {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Habit.name": %w`, err)}
 		}
 	}
+	if v, ok := hu.mutation.Color(); ok {
+		if err := habit.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Habit.color": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Schedule(); ok {
+		if err := habit.ScheduleValidator(v); err != nil {
+			return &ValidationError{Name: "schedule", err: fmt.Errorf(`ent: validator failed for field "Habit.schedule": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.TimesPerPeriod(); ok {
+		if err := habit.TimesPerPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "times_per_period", err: fmt.Errorf(`ent: validator failed for field "Habit.times_per_period": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.IntervalDays(); ok {
+		if err := habit.IntervalDaysValidator(v); err != nil {
+			return &ValidationError{Name: "interval_days", err: fmt.Errorf(`ent: validator failed for field "Habit.interval_days": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Polarity(); ok {
+		if err := habit.PolarityValidator(v); err != nil {
+			return &ValidationError{Name: "polarity", err: fmt.Errorf(`ent: validator failed for field "Habit.polarity": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.GetType(); ok {
+		if err := habit.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Habit.type": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Target(); ok {
+		if err := habit.TargetValidator(v); err != nil {
+			return &ValidationError{Name: "target", err: fmt.Errorf(`ent: validator failed for field "Habit.target": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.TargetDirection(); ok {
+		if err := habit.TargetDirectionValidator(v); err != nil {
+			return &ValidationError{Name: "target_direction", err: fmt.Errorf(`ent: validator failed for field "Habit.target_direction": %w`, err)}
+		}
+	}
+	if hu.mutation.OwnerCleared() && len(hu.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Habit.owner"`)
+	}
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (hu *HabitUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *HabitUpdate {
+	hu.modifiers = append(hu.modifiers, modifiers...)
+	return hu
+}
+
 func (hu *HabitUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if err := hu.check(); err != nil {
 		return n, err
@@ -133,15 +562,170 @@ func (hu *HabitUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := hu.mutation.Name(); ok {
 		_spec.SetField(habit.FieldName, field.TypeString, value)
 	}
+	if value, ok := hu.mutation.NameKey(); ok {
+		_spec.SetField(habit.FieldNameKey, field.TypeString, value)
+	}
+	if hu.mutation.NameKeyCleared() {
+		_spec.ClearField(habit.FieldNameKey, field.TypeString)
+	}
 	if value, ok := hu.mutation.Description(); ok {
 		_spec.SetField(habit.FieldDescription, field.TypeString, value)
 	}
 	if hu.mutation.DescriptionCleared() {
 		_spec.ClearField(habit.FieldDescription, field.TypeString)
 	}
+	if value, ok := hu.mutation.Color(); ok {
+		_spec.SetField(habit.FieldColor, field.TypeString, value)
+	}
+	if hu.mutation.ColorCleared() {
+		_spec.ClearField(habit.FieldColor, field.TypeString)
+	}
 	if value, ok := hu.mutation.CreatedAt(); ok {
 		_spec.SetField(habit.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := hu.mutation.Schedule(); ok {
+		_spec.SetField(habit.FieldSchedule, field.TypeEnum, value)
+	}
+	if value, ok := hu.mutation.Weekdays(); ok {
+		_spec.SetField(habit.FieldWeekdays, field.TypeJSON, value)
+	}
+	if value, ok := hu.mutation.AppendedWeekdays(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, habit.FieldWeekdays, value)
+		})
+	}
+	if hu.mutation.WeekdaysCleared() {
+		_spec.ClearField(habit.FieldWeekdays, field.TypeJSON)
+	}
+	if value, ok := hu.mutation.TimesPerPeriod(); ok {
+		_spec.SetField(habit.FieldTimesPerPeriod, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.AddedTimesPerPeriod(); ok {
+		_spec.AddField(habit.FieldTimesPerPeriod, field.TypeInt, value)
+	}
+	if hu.mutation.TimesPerPeriodCleared() {
+		_spec.ClearField(habit.FieldTimesPerPeriod, field.TypeInt)
+	}
+	if value, ok := hu.mutation.IntervalDays(); ok {
+		_spec.SetField(habit.FieldIntervalDays, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.AddedIntervalDays(); ok {
+		_spec.AddField(habit.FieldIntervalDays, field.TypeInt, value)
+	}
+	if hu.mutation.IntervalDaysCleared() {
+		_spec.ClearField(habit.FieldIntervalDays, field.TypeInt)
+	}
+	if value, ok := hu.mutation.Rrule(); ok {
+		_spec.SetField(habit.FieldRrule, field.TypeString, value)
+	}
+	if hu.mutation.RruleCleared() {
+		_spec.ClearField(habit.FieldRrule, field.TypeString)
+	}
+	if value, ok := hu.mutation.Polarity(); ok {
+		_spec.SetField(habit.FieldPolarity, field.TypeEnum, value)
+	}
+	if value, ok := hu.mutation.GetType(); ok {
+		_spec.SetField(habit.FieldType, field.TypeEnum, value)
+	}
+	if value, ok := hu.mutation.Unit(); ok {
+		_spec.SetField(habit.FieldUnit, field.TypeString, value)
+	}
+	if hu.mutation.UnitCleared() {
+		_spec.ClearField(habit.FieldUnit, field.TypeString)
+	}
+	if value, ok := hu.mutation.Target(); ok {
+		_spec.SetField(habit.FieldTarget, field.TypeFloat64, value)
+	}
+	if value, ok := hu.mutation.AddedTarget(); ok {
+		_spec.AddField(habit.FieldTarget, field.TypeFloat64, value)
+	}
+	if hu.mutation.TargetCleared() {
+		_spec.ClearField(habit.FieldTarget, field.TypeFloat64)
+	}
+	if value, ok := hu.mutation.TargetDirection(); ok {
+		_spec.SetField(habit.FieldTargetDirection, field.TypeEnum, value)
+	}
+	if hu.mutation.TargetDirectionCleared() {
+		_spec.ClearField(habit.FieldTargetDirection, field.TypeEnum)
+	}
+	if value, ok := hu.mutation.Tags(); ok {
+		_spec.SetField(habit.FieldTags, field.TypeJSON, value)
+	}
+	if value, ok := hu.mutation.AppendedTags(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, habit.FieldTags, value)
+		})
+	}
+	if hu.mutation.TagsCleared() {
+		_spec.ClearField(habit.FieldTags, field.TypeJSON)
+	}
+	if value, ok := hu.mutation.Archived(); ok {
+		_spec.SetField(habit.FieldArchived, field.TypeBool, value)
+	}
+	if value, ok := hu.mutation.Version(); ok {
+		_spec.SetField(habit.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.AddedVersion(); ok {
+		_spec.AddField(habit.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.UpdatedAt(); ok {
+		_spec.SetField(habit.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if hu.mutation.UpdatedAtCleared() {
+		_spec.ClearField(habit.FieldUpdatedAt, field.TypeTime)
+	}
+	if value, ok := hu.mutation.DeletedAt(); ok {
+		_spec.SetField(habit.FieldDeletedAt, field.TypeTime, value)
+	}
+	if hu.mutation.DeletedAtCleared() {
+		_spec.ClearField(habit.FieldDeletedAt, field.TypeTime)
+	}
+	if hu.mutation.CompletionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.RemovedCompletionsIDs(); len(nodes) > 0 && !hu.mutation.CompletionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.CompletionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(hu.modifiers...)
 	if n, err = sqlgraph.UpdateNodes(ctx, hu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{habit.Label}
@@ -157,9 +741,10 @@ func (hu *HabitUpdate) sqlSave(ctx context.Context) (n int, err error) {
 // HabitUpdateOne is the builder for updating a single Habit entity.
 type HabitUpdateOne struct {
 	config
-	fields   []string
-	hooks    []Hook
-	mutation *HabitMutation
+	fields    []string
+	hooks     []Hook
+	mutation  *HabitMutation
+	modifiers []func(*sql.UpdateBuilder)
 }
 
 // SetName sets the "name" field.
@@ -176,6 +761,26 @@ func (huo *HabitUpdateOne) SetNillableName(s *string) *HabitUpdateOne {
 	return huo
 }
 
+// SetNameKey sets the "name_key" field.
+func (huo *HabitUpdateOne) SetNameKey(s string) *HabitUpdateOne {
+	huo.mutation.SetNameKey(s)
+	return huo
+}
+
+// SetNillableNameKey sets the "name_key" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableNameKey(s *string) *HabitUpdateOne {
+	if s != nil {
+		huo.SetNameKey(*s)
+	}
+	return huo
+}
+
+// ClearNameKey clears the value of the "name_key" field.
+func (huo *HabitUpdateOne) ClearNameKey() *HabitUpdateOne {
+	huo.mutation.ClearNameKey()
+	return huo
+}
+
 // SetDescription sets the "description" field.
 func (huo *HabitUpdateOne) SetDescription(s string) *HabitUpdateOne {
 	huo.mutation.SetDescription(s)
@@ -196,6 +801,26 @@ func (huo *HabitUpdateOne) ClearDescription() *HabitUpdateOne {
 	return huo
 }
 
+// SetColor sets the "color" field.
+func (huo *HabitUpdateOne) SetColor(s string) *HabitUpdateOne {
+	huo.mutation.SetColor(s)
+	return huo
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableColor(s *string) *HabitUpdateOne {
+	if s != nil {
+		huo.SetColor(*s)
+	}
+	return huo
+}
+
+// ClearColor clears the value of the "color" field.
+func (huo *HabitUpdateOne) ClearColor() *HabitUpdateOne {
+	huo.mutation.ClearColor()
+	return huo
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (huo *HabitUpdateOne) SetCreatedAt(t time.Time) *HabitUpdateOne {
 	huo.mutation.SetCreatedAt(t)
@@ -210,11 +835,333 @@ func (huo *HabitUpdateOne) SetNillableCreatedAt(t *time.Time) *HabitUpdateOne {
 	return huo
 }
 
+// SetSchedule sets the "schedule" field.
+func (huo *HabitUpdateOne) SetSchedule(h habit.Schedule) *HabitUpdateOne {
+	huo.mutation.SetSchedule(h)
+	return huo
+}
+
+// SetNillableSchedule sets the "schedule" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableSchedule(h *habit.Schedule) *HabitUpdateOne {
+	if h != nil {
+		huo.SetSchedule(*h)
+	}
+	return huo
+}
+
+// SetWeekdays sets the "weekdays" field.
+func (huo *HabitUpdateOne) SetWeekdays(i []int) *HabitUpdateOne {
+	huo.mutation.SetWeekdays(i)
+	return huo
+}
+
+// AppendWeekdays appends i to the "weekdays" field.
+func (huo *HabitUpdateOne) AppendWeekdays(i []int) *HabitUpdateOne {
+	huo.mutation.AppendWeekdays(i)
+	return huo
+}
+
+// ClearWeekdays clears the value of the "weekdays" field.
+func (huo *HabitUpdateOne) ClearWeekdays() *HabitUpdateOne {
+	huo.mutation.ClearWeekdays()
+	return huo
+}
+
+// SetTimesPerPeriod sets the "times_per_period" field.
+func (huo *HabitUpdateOne) SetTimesPerPeriod(i int) *HabitUpdateOne {
+	huo.mutation.ResetTimesPerPeriod()
+	huo.mutation.SetTimesPerPeriod(i)
+	return huo
+}
+
+// SetNillableTimesPerPeriod sets the "times_per_period" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableTimesPerPeriod(i *int) *HabitUpdateOne {
+	if i != nil {
+		huo.SetTimesPerPeriod(*i)
+	}
+	return huo
+}
+
+// AddTimesPerPeriod adds i to the "times_per_period" field.
+func (huo *HabitUpdateOne) AddTimesPerPeriod(i int) *HabitUpdateOne {
+	huo.mutation.AddTimesPerPeriod(i)
+	return huo
+}
+
+// ClearTimesPerPeriod clears the value of the "times_per_period" field.
+func (huo *HabitUpdateOne) ClearTimesPerPeriod() *HabitUpdateOne {
+	huo.mutation.ClearTimesPerPeriod()
+	return huo
+}
+
+// SetIntervalDays sets the "interval_days" field.
+func (huo *HabitUpdateOne) SetIntervalDays(i int) *HabitUpdateOne {
+	huo.mutation.ResetIntervalDays()
+	huo.mutation.SetIntervalDays(i)
+	return huo
+}
+
+// SetNillableIntervalDays sets the "interval_days" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableIntervalDays(i *int) *HabitUpdateOne {
+	if i != nil {
+		huo.SetIntervalDays(*i)
+	}
+	return huo
+}
+
+// AddIntervalDays adds i to the "interval_days" field.
+func (huo *HabitUpdateOne) AddIntervalDays(i int) *HabitUpdateOne {
+	huo.mutation.AddIntervalDays(i)
+	return huo
+}
+
+// ClearIntervalDays clears the value of the "interval_days" field.
+func (huo *HabitUpdateOne) ClearIntervalDays() *HabitUpdateOne {
+	huo.mutation.ClearIntervalDays()
+	return huo
+}
+
+// SetRrule sets the "rrule" field.
+func (huo *HabitUpdateOne) SetRrule(s string) *HabitUpdateOne {
+	huo.mutation.SetRrule(s)
+	return huo
+}
+
+// SetNillableRrule sets the "rrule" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableRrule(s *string) *HabitUpdateOne {
+	if s != nil {
+		huo.SetRrule(*s)
+	}
+	return huo
+}
+
+// ClearRrule clears the value of the "rrule" field.
+func (huo *HabitUpdateOne) ClearRrule() *HabitUpdateOne {
+	huo.mutation.ClearRrule()
+	return huo
+}
+
+// SetPolarity sets the "polarity" field.
+func (huo *HabitUpdateOne) SetPolarity(h habit.Polarity) *HabitUpdateOne {
+	huo.mutation.SetPolarity(h)
+	return huo
+}
+
+// SetNillablePolarity sets the "polarity" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillablePolarity(h *habit.Polarity) *HabitUpdateOne {
+	if h != nil {
+		huo.SetPolarity(*h)
+	}
+	return huo
+}
+
+// SetType sets the "type" field.
+func (huo *HabitUpdateOne) SetType(h habit.Type) *HabitUpdateOne {
+	huo.mutation.SetType(h)
+	return huo
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableType(h *habit.Type) *HabitUpdateOne {
+	if h != nil {
+		huo.SetType(*h)
+	}
+	return huo
+}
+
+// SetUnit sets the "unit" field.
+func (huo *HabitUpdateOne) SetUnit(s string) *HabitUpdateOne {
+	huo.mutation.SetUnit(s)
+	return huo
+}
+
+// SetNillableUnit sets the "unit" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableUnit(s *string) *HabitUpdateOne {
+	if s != nil {
+		huo.SetUnit(*s)
+	}
+	return huo
+}
+
+// ClearUnit clears the value of the "unit" field.
+func (huo *HabitUpdateOne) ClearUnit() *HabitUpdateOne {
+	huo.mutation.ClearUnit()
+	return huo
+}
+
+// SetTarget sets the "target" field.
+func (huo *HabitUpdateOne) SetTarget(f float64) *HabitUpdateOne {
+	huo.mutation.ResetTarget()
+	huo.mutation.SetTarget(f)
+	return huo
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableTarget(f *float64) *HabitUpdateOne {
+	if f != nil {
+		huo.SetTarget(*f)
+	}
+	return huo
+}
+
+// AddTarget adds f to the "target" field.
+func (huo *HabitUpdateOne) AddTarget(f float64) *HabitUpdateOne {
+	huo.mutation.AddTarget(f)
+	return huo
+}
+
+// ClearTarget clears the value of the "target" field.
+func (huo *HabitUpdateOne) ClearTarget() *HabitUpdateOne {
+	huo.mutation.ClearTarget()
+	return huo
+}
+
+// SetTargetDirection sets the "target_direction" field.
+func (huo *HabitUpdateOne) SetTargetDirection(hd habit.TargetDirection) *HabitUpdateOne {
+	huo.mutation.SetTargetDirection(hd)
+	return huo
+}
+
+// SetNillableTargetDirection sets the "target_direction" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableTargetDirection(hd *habit.TargetDirection) *HabitUpdateOne {
+	if hd != nil {
+		huo.SetTargetDirection(*hd)
+	}
+	return huo
+}
+
+// ClearTargetDirection clears the value of the "target_direction" field.
+func (huo *HabitUpdateOne) ClearTargetDirection() *HabitUpdateOne {
+	huo.mutation.ClearTargetDirection()
+	return huo
+}
+
+// SetTags sets the "tags" field.
+func (huo *HabitUpdateOne) SetTags(s []string) *HabitUpdateOne {
+	huo.mutation.SetTags(s)
+	return huo
+}
+
+// AppendTags appends s to the "tags" field.
+func (huo *HabitUpdateOne) AppendTags(s []string) *HabitUpdateOne {
+	huo.mutation.AppendTags(s)
+	return huo
+}
+
+// ClearTags clears the value of the "tags" field.
+func (huo *HabitUpdateOne) ClearTags() *HabitUpdateOne {
+	huo.mutation.ClearTags()
+	return huo
+}
+
+// SetArchived sets the "archived" field.
+func (huo *HabitUpdateOne) SetArchived(b bool) *HabitUpdateOne {
+	huo.mutation.SetArchived(b)
+	return huo
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableArchived(b *bool) *HabitUpdateOne {
+	if b != nil {
+		huo.SetArchived(*b)
+	}
+	return huo
+}
+
+// SetVersion sets the "version" field.
+func (huo *HabitUpdateOne) SetVersion(i int) *HabitUpdateOne {
+	huo.mutation.ResetVersion()
+	huo.mutation.SetVersion(i)
+	return huo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableVersion(i *int) *HabitUpdateOne {
+	if i != nil {
+		huo.SetVersion(*i)
+	}
+	return huo
+}
+
+// AddVersion adds i to the "version" field.
+func (huo *HabitUpdateOne) AddVersion(i int) *HabitUpdateOne {
+	huo.mutation.AddVersion(i)
+	return huo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (huo *HabitUpdateOne) SetUpdatedAt(t time.Time) *HabitUpdateOne {
+	huo.mutation.SetUpdatedAt(t)
+	return huo
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (huo *HabitUpdateOne) ClearUpdatedAt() *HabitUpdateOne {
+	huo.mutation.ClearUpdatedAt()
+	return huo
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (huo *HabitUpdateOne) SetDeletedAt(t time.Time) *HabitUpdateOne {
+	huo.mutation.SetDeletedAt(t)
+	return huo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (huo *HabitUpdateOne) SetNillableDeletedAt(t *time.Time) *HabitUpdateOne {
+	if t != nil {
+		huo.SetDeletedAt(*t)
+	}
+	return huo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (huo *HabitUpdateOne) ClearDeletedAt() *HabitUpdateOne {
+	huo.mutation.ClearDeletedAt()
+	return huo
+}
+
+// AddCompletionIDs adds the "completions" edge to the Completion entity by IDs.
+func (huo *HabitUpdateOne) AddCompletionIDs(ids ...int) *HabitUpdateOne {
+	huo.mutation.AddCompletionIDs(ids...)
+	return huo
+}
+
+// AddCompletions adds the "completions" edges to the Completion entity.
+func (huo *HabitUpdateOne) AddCompletions(c ...*Completion) *HabitUpdateOne {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return huo.AddCompletionIDs(ids...)
+}
+
 // Mutation returns the HabitMutation object of the builder.
 func (huo *HabitUpdateOne) Mutation() *HabitMutation {
 	return huo.mutation
 }
 
+// ClearCompletions clears all "completions" edges to the Completion entity.
+func (huo *HabitUpdateOne) ClearCompletions() *HabitUpdateOne {
+	huo.mutation.ClearCompletions()
+	return huo
+}
+
+// RemoveCompletionIDs removes the "completions" edge to Completion entities by IDs.
+func (huo *HabitUpdateOne) RemoveCompletionIDs(ids ...int) *HabitUpdateOne {
+	huo.mutation.RemoveCompletionIDs(ids...)
+	return huo
+}
+
+// RemoveCompletions removes "completions" edges to Completion entities.
+func (huo *HabitUpdateOne) RemoveCompletions(c ...*Completion) *HabitUpdateOne {
+	ids := make([]int, len(c))
+	for i := range c {
+		ids[i] = c[i].ID
+	}
+	return huo.RemoveCompletionIDs(ids...)
+}
+
 // Where appends a list predicates to the HabitUpdate builder.
 func (huo *HabitUpdateOne) Where(ps ...predicate.Habit) *HabitUpdateOne {
 	huo.mutation.Where(ps...)
@@ -230,6 +1177,9 @@ func (huo *HabitUpdateOne) Select(field string, fields ...string) *HabitUpdateOn
 
 // Save executes the query and returns the updated Habit entity.
 func (huo *HabitUpdateOne) Save(ctx context.Context) (*Habit, error) {
+	if err := huo.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, huo.sqlSave, huo.mutation, huo.hooks)
 }
 
@@ -255,6 +1205,18 @@ func (huo *HabitUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (huo *HabitUpdateOne) defaults() error {
+	if _, ok := huo.mutation.UpdatedAt(); !ok && !huo.mutation.UpdatedAtCleared() {
+		if habit.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized habit.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := habit.UpdateDefaultUpdatedAt()
+		huo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (huo *HabitUpdateOne) check() error {
 	if v, ok := huo.mutation.Name(); ok {
@@ -262,9 +1224,58 @@ func (huo *HabitUpdateOne) check() error {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Habit.name": %w`, err)}
 		}
 	}
+	if v, ok := huo.mutation.Color(); ok {
+		if err := habit.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Habit.color": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Schedule(); ok {
+		if err := habit.ScheduleValidator(v); err != nil {
+			return &ValidationError{Name: "schedule", err: fmt.Errorf(`ent: validator failed for field "Habit.schedule": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.TimesPerPeriod(); ok {
+		if err := habit.TimesPerPeriodValidator(v); err != nil {
+			return &ValidationError{Name: "times_per_period", err: fmt.Errorf(`ent: validator failed for field "Habit.times_per_period": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.IntervalDays(); ok {
+		if err := habit.IntervalDaysValidator(v); err != nil {
+			return &ValidationError{Name: "interval_days", err: fmt.Errorf(`ent: validator failed for field "Habit.interval_days": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Polarity(); ok {
+		if err := habit.PolarityValidator(v); err != nil {
+			return &ValidationError{Name: "polarity", err: fmt.Errorf(`ent: validator failed for field "Habit.polarity": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.GetType(); ok {
+		if err := habit.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Habit.type": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Target(); ok {
+		if err := habit.TargetValidator(v); err != nil {
+			return &ValidationError{Name: "target", err: fmt.Errorf(`ent: validator failed for field "Habit.target": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.TargetDirection(); ok {
+		if err := habit.TargetDirectionValidator(v); err != nil {
+			return &ValidationError{Name: "target_direction", err: fmt.Errorf(`ent: validator failed for field "Habit.target_direction": %w`, err)}
+		}
+	}
+	if huo.mutation.OwnerCleared() && len(huo.mutation.OwnerIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Habit.owner"`)
+	}
 	return nil
 }
 
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (huo *HabitUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *HabitUpdateOne {
+	huo.modifiers = append(huo.modifiers, modifiers...)
+	return huo
+}
+
 func (huo *HabitUpdateOne) sqlSave(ctx context.Context) (_node *Habit, err error) {
 	if err := huo.check(); err != nil {
 		return _node, err
@@ -297,15 +1308,170 @@ func (huo *HabitUpdateOne) sqlSave(ctx context.Context) (_node *Habit, err error
 	if value, ok := huo.mutation.Name(); ok {
 		_spec.SetField(habit.FieldName, field.TypeString, value)
 	}
+	if value, ok := huo.mutation.NameKey(); ok {
+		_spec.SetField(habit.FieldNameKey, field.TypeString, value)
+	}
+	if huo.mutation.NameKeyCleared() {
+		_spec.ClearField(habit.FieldNameKey, field.TypeString)
+	}
 	if value, ok := huo.mutation.Description(); ok {
 		_spec.SetField(habit.FieldDescription, field.TypeString, value)
 	}
 	if huo.mutation.DescriptionCleared() {
 		_spec.ClearField(habit.FieldDescription, field.TypeString)
 	}
+	if value, ok := huo.mutation.Color(); ok {
+		_spec.SetField(habit.FieldColor, field.TypeString, value)
+	}
+	if huo.mutation.ColorCleared() {
+		_spec.ClearField(habit.FieldColor, field.TypeString)
+	}
 	if value, ok := huo.mutation.CreatedAt(); ok {
 		_spec.SetField(habit.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := huo.mutation.Schedule(); ok {
+		_spec.SetField(habit.FieldSchedule, field.TypeEnum, value)
+	}
+	if value, ok := huo.mutation.Weekdays(); ok {
+		_spec.SetField(habit.FieldWeekdays, field.TypeJSON, value)
+	}
+	if value, ok := huo.mutation.AppendedWeekdays(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, habit.FieldWeekdays, value)
+		})
+	}
+	if huo.mutation.WeekdaysCleared() {
+		_spec.ClearField(habit.FieldWeekdays, field.TypeJSON)
+	}
+	if value, ok := huo.mutation.TimesPerPeriod(); ok {
+		_spec.SetField(habit.FieldTimesPerPeriod, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.AddedTimesPerPeriod(); ok {
+		_spec.AddField(habit.FieldTimesPerPeriod, field.TypeInt, value)
+	}
+	if huo.mutation.TimesPerPeriodCleared() {
+		_spec.ClearField(habit.FieldTimesPerPeriod, field.TypeInt)
+	}
+	if value, ok := huo.mutation.IntervalDays(); ok {
+		_spec.SetField(habit.FieldIntervalDays, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.AddedIntervalDays(); ok {
+		_spec.AddField(habit.FieldIntervalDays, field.TypeInt, value)
+	}
+	if huo.mutation.IntervalDaysCleared() {
+		_spec.ClearField(habit.FieldIntervalDays, field.TypeInt)
+	}
+	if value, ok := huo.mutation.Rrule(); ok {
+		_spec.SetField(habit.FieldRrule, field.TypeString, value)
+	}
+	if huo.mutation.RruleCleared() {
+		_spec.ClearField(habit.FieldRrule, field.TypeString)
+	}
+	if value, ok := huo.mutation.Polarity(); ok {
+		_spec.SetField(habit.FieldPolarity, field.TypeEnum, value)
+	}
+	if value, ok := huo.mutation.GetType(); ok {
+		_spec.SetField(habit.FieldType, field.TypeEnum, value)
+	}
+	if value, ok := huo.mutation.Unit(); ok {
+		_spec.SetField(habit.FieldUnit, field.TypeString, value)
+	}
+	if huo.mutation.UnitCleared() {
+		_spec.ClearField(habit.FieldUnit, field.TypeString)
+	}
+	if value, ok := huo.mutation.Target(); ok {
+		_spec.SetField(habit.FieldTarget, field.TypeFloat64, value)
+	}
+	if value, ok := huo.mutation.AddedTarget(); ok {
+		_spec.AddField(habit.FieldTarget, field.TypeFloat64, value)
+	}
+	if huo.mutation.TargetCleared() {
+		_spec.ClearField(habit.FieldTarget, field.TypeFloat64)
+	}
+	if value, ok := huo.mutation.TargetDirection(); ok {
+		_spec.SetField(habit.FieldTargetDirection, field.TypeEnum, value)
+	}
+	if huo.mutation.TargetDirectionCleared() {
+		_spec.ClearField(habit.FieldTargetDirection, field.TypeEnum)
+	}
+	if value, ok := huo.mutation.Tags(); ok {
+		_spec.SetField(habit.FieldTags, field.TypeJSON, value)
+	}
+	if value, ok := huo.mutation.AppendedTags(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, habit.FieldTags, value)
+		})
+	}
+	if huo.mutation.TagsCleared() {
+		_spec.ClearField(habit.FieldTags, field.TypeJSON)
+	}
+	if value, ok := huo.mutation.Archived(); ok {
+		_spec.SetField(habit.FieldArchived, field.TypeBool, value)
+	}
+	if value, ok := huo.mutation.Version(); ok {
+		_spec.SetField(habit.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.AddedVersion(); ok {
+		_spec.AddField(habit.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.UpdatedAt(); ok {
+		_spec.SetField(habit.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if huo.mutation.UpdatedAtCleared() {
+		_spec.ClearField(habit.FieldUpdatedAt, field.TypeTime)
+	}
+	if value, ok := huo.mutation.DeletedAt(); ok {
+		_spec.SetField(habit.FieldDeletedAt, field.TypeTime, value)
+	}
+	if huo.mutation.DeletedAtCleared() {
+		_spec.ClearField(habit.FieldDeletedAt, field.TypeTime)
+	}
+	if huo.mutation.CompletionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.RemovedCompletionsIDs(); len(nodes) > 0 && !huo.mutation.CompletionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.CompletionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   habit.CompletionsTable,
+			Columns: []string{habit.CompletionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(completion.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_spec.AddModifiers(huo.modifiers...)
 	_node = &Habit{config: huo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,357 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api/ent/idempotencykey"
+	"api/ent/predicate"
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IdempotencyKeyUpdate is the builder for updating IdempotencyKey entities.
+type IdempotencyKeyUpdate struct {
+	config
+	hooks     []Hook
+	mutation  *IdempotencyKeyMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// Where appends a list predicates to the IdempotencyKeyUpdate builder.
+func (iku *IdempotencyKeyUpdate) Where(ps ...predicate.IdempotencyKey) *IdempotencyKeyUpdate {
+	iku.mutation.Where(ps...)
+	return iku
+}
+
+// SetStatus sets the "status" field.
+func (iku *IdempotencyKeyUpdate) SetStatus(i int) *IdempotencyKeyUpdate {
+	iku.mutation.ResetStatus()
+	iku.mutation.SetStatus(i)
+	return iku
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (iku *IdempotencyKeyUpdate) SetNillableStatus(i *int) *IdempotencyKeyUpdate {
+	if i != nil {
+		iku.SetStatus(*i)
+	}
+	return iku
+}
+
+// AddStatus adds i to the "status" field.
+func (iku *IdempotencyKeyUpdate) AddStatus(i int) *IdempotencyKeyUpdate {
+	iku.mutation.AddStatus(i)
+	return iku
+}
+
+// ClearStatus clears the value of the "status" field.
+func (iku *IdempotencyKeyUpdate) ClearStatus() *IdempotencyKeyUpdate {
+	iku.mutation.ClearStatus()
+	return iku
+}
+
+// SetHeader sets the "header" field.
+func (iku *IdempotencyKeyUpdate) SetHeader(m map[string]string) *IdempotencyKeyUpdate {
+	iku.mutation.SetHeader(m)
+	return iku
+}
+
+// ClearHeader clears the value of the "header" field.
+func (iku *IdempotencyKeyUpdate) ClearHeader() *IdempotencyKeyUpdate {
+	iku.mutation.ClearHeader()
+	return iku
+}
+
+// SetBody sets the "body" field.
+func (iku *IdempotencyKeyUpdate) SetBody(b []byte) *IdempotencyKeyUpdate {
+	iku.mutation.SetBody(b)
+	return iku
+}
+
+// ClearBody clears the value of the "body" field.
+func (iku *IdempotencyKeyUpdate) ClearBody() *IdempotencyKeyUpdate {
+	iku.mutation.ClearBody()
+	return iku
+}
+
+// Mutation returns the IdempotencyKeyMutation object of the builder.
+func (iku *IdempotencyKeyUpdate) Mutation() *IdempotencyKeyMutation {
+	return iku.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iku *IdempotencyKeyUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, iku.sqlSave, iku.mutation, iku.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iku *IdempotencyKeyUpdate) SaveX(ctx context.Context) int {
+	affected, err := iku.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iku *IdempotencyKeyUpdate) Exec(ctx context.Context) error {
+	_, err := iku.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iku *IdempotencyKeyUpdate) ExecX(ctx context.Context) {
+	if err := iku.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iku *IdempotencyKeyUpdate) check() error {
+	if iku.mutation.UserCleared() && len(iku.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "IdempotencyKey.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (iku *IdempotencyKeyUpdate) Modify(modifiers ...func(u *sql.UpdateBuilder)) *IdempotencyKeyUpdate {
+	iku.modifiers = append(iku.modifiers, modifiers...)
+	return iku
+}
+
+func (iku *IdempotencyKeyUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := iku.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(idempotencykey.Table, idempotencykey.Columns, sqlgraph.NewFieldSpec(idempotencykey.FieldID, field.TypeInt))
+	if ps := iku.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iku.mutation.Status(); ok {
+		_spec.SetField(idempotencykey.FieldStatus, field.TypeInt, value)
+	}
+	if value, ok := iku.mutation.AddedStatus(); ok {
+		_spec.AddField(idempotencykey.FieldStatus, field.TypeInt, value)
+	}
+	if iku.mutation.StatusCleared() {
+		_spec.ClearField(idempotencykey.FieldStatus, field.TypeInt)
+	}
+	if value, ok := iku.mutation.Header(); ok {
+		_spec.SetField(idempotencykey.FieldHeader, field.TypeJSON, value)
+	}
+	if iku.mutation.HeaderCleared() {
+		_spec.ClearField(idempotencykey.FieldHeader, field.TypeJSON)
+	}
+	if value, ok := iku.mutation.Body(); ok {
+		_spec.SetField(idempotencykey.FieldBody, field.TypeBytes, value)
+	}
+	if iku.mutation.BodyCleared() {
+		_spec.ClearField(idempotencykey.FieldBody, field.TypeBytes)
+	}
+	_spec.AddModifiers(iku.modifiers...)
+	if n, err = sqlgraph.UpdateNodes(ctx, iku.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{idempotencykey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iku.mutation.done = true
+	return n, nil
+}
+
+// IdempotencyKeyUpdateOne is the builder for updating a single IdempotencyKey entity.
+type IdempotencyKeyUpdateOne struct {
+	config
+	fields    []string
+	hooks     []Hook
+	mutation  *IdempotencyKeyMutation
+	modifiers []func(*sql.UpdateBuilder)
+}
+
+// SetStatus sets the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetStatus(i int) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ResetStatus()
+	ikuo.mutation.SetStatus(i)
+	return ikuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ikuo *IdempotencyKeyUpdateOne) SetNillableStatus(i *int) *IdempotencyKeyUpdateOne {
+	if i != nil {
+		ikuo.SetStatus(*i)
+	}
+	return ikuo
+}
+
+// AddStatus adds i to the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) AddStatus(i int) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.AddStatus(i)
+	return ikuo
+}
+
+// ClearStatus clears the value of the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearStatus() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearStatus()
+	return ikuo
+}
+
+// SetHeader sets the "header" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetHeader(m map[string]string) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetHeader(m)
+	return ikuo
+}
+
+// ClearHeader clears the value of the "header" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearHeader() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearHeader()
+	return ikuo
+}
+
+// SetBody sets the "body" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetBody(b []byte) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetBody(b)
+	return ikuo
+}
+
+// ClearBody clears the value of the "body" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearBody() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearBody()
+	return ikuo
+}
+
+// Mutation returns the IdempotencyKeyMutation object of the builder.
+func (ikuo *IdempotencyKeyUpdateOne) Mutation() *IdempotencyKeyMutation {
+	return ikuo.mutation
+}
+
+// Where appends a list predicates to the IdempotencyKeyUpdate builder.
+func (ikuo *IdempotencyKeyUpdateOne) Where(ps ...predicate.IdempotencyKey) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.Where(ps...)
+	return ikuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ikuo *IdempotencyKeyUpdateOne) Select(field string, fields ...string) *IdempotencyKeyUpdateOne {
+	ikuo.fields = append([]string{field}, fields...)
+	return ikuo
+}
+
+// Save executes the query and returns the updated IdempotencyKey entity.
+func (ikuo *IdempotencyKeyUpdateOne) Save(ctx context.Context) (*IdempotencyKey, error) {
+	return withHooks(ctx, ikuo.sqlSave, ikuo.mutation, ikuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ikuo *IdempotencyKeyUpdateOne) SaveX(ctx context.Context) *IdempotencyKey {
+	node, err := ikuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ikuo *IdempotencyKeyUpdateOne) Exec(ctx context.Context) error {
+	_, err := ikuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ikuo *IdempotencyKeyUpdateOne) ExecX(ctx context.Context) {
+	if err := ikuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ikuo *IdempotencyKeyUpdateOne) check() error {
+	if ikuo.mutation.UserCleared() && len(ikuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "IdempotencyKey.user"`)
+	}
+	return nil
+}
+
+// Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
+func (ikuo *IdempotencyKeyUpdateOne) Modify(modifiers ...func(u *sql.UpdateBuilder)) *IdempotencyKeyUpdateOne {
+	ikuo.modifiers = append(ikuo.modifiers, modifiers...)
+	return ikuo
+}
+
+func (ikuo *IdempotencyKeyUpdateOne) sqlSave(ctx context.Context) (_node *IdempotencyKey, err error) {
+	if err := ikuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(idempotencykey.Table, idempotencykey.Columns, sqlgraph.NewFieldSpec(idempotencykey.FieldID, field.TypeInt))
+	id, ok := ikuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "IdempotencyKey.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ikuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, idempotencykey.FieldID)
+		for _, f := range fields {
+			if !idempotencykey.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != idempotencykey.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ikuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ikuo.mutation.Status(); ok {
+		_spec.SetField(idempotencykey.FieldStatus, field.TypeInt, value)
+	}
+	if value, ok := ikuo.mutation.AddedStatus(); ok {
+		_spec.AddField(idempotencykey.FieldStatus, field.TypeInt, value)
+	}
+	if ikuo.mutation.StatusCleared() {
+		_spec.ClearField(idempotencykey.FieldStatus, field.TypeInt)
+	}
+	if value, ok := ikuo.mutation.Header(); ok {
+		_spec.SetField(idempotencykey.FieldHeader, field.TypeJSON, value)
+	}
+	if ikuo.mutation.HeaderCleared() {
+		_spec.ClearField(idempotencykey.FieldHeader, field.TypeJSON)
+	}
+	if value, ok := ikuo.mutation.Body(); ok {
+		_spec.SetField(idempotencykey.FieldBody, field.TypeBytes, value)
+	}
+	if ikuo.mutation.BodyCleared() {
+		_spec.ClearField(idempotencykey.FieldBody, field.TypeBytes)
+	}
+	_spec.AddModifiers(ikuo.modifiers...)
+	_node = &IdempotencyKey{config: ikuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ikuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{idempotencykey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ikuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,65 @@
+//go:build ignore
+
+// Command main generates a new versioned migration from the difference
+// between the ent schema and the migrations already in the directory of the
+// chosen dialect. The existing migrations are replayed on the dev database,
+// which must be empty, so it never touches real data:
+//
+//	go run -mod=mod ent/migrate/main.go -dialect sqlite add_habit_color
+//	go run -mod=mod ent/migrate/main.go -dialect postgres \
+//		-dev-url "postgres://hire:me@localhost:5432/dev?sslmode=disable" add_habit_color
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"api/database"
+	"api/ent/migrate"
+
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	driver := flag.String("dialect", database.SQLite, "postgres or sqlite")
+	devURL := flag.String("dev-url", "", "empty database used to compute the diff, defaults to an in-memory SQLite database")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatalln("usage: go run -mod=mod ent/migrate/main.go [-dialect sqlite|postgres] [-dev-url URL] <name>")
+	}
+	entDialect := map[string]string{
+		database.Postgres: dialect.Postgres,
+		database.SQLite:   dialect.SQLite,
+	}[*driver]
+	if entDialect == "" {
+		log.Fatalf("unsupported dialect %q", *driver)
+	}
+	if *devURL == "" {
+		if *driver != database.SQLite {
+			log.Fatalln("-dev-url is required for", *driver)
+		}
+		*devURL = "sqlite://dev?mode=memory&_fk=1"
+	}
+
+	dir, err := sqltool.NewGolangMigrateDir("ent/migrate/migrations/" + *driver)
+	if err != nil {
+		log.Fatalf("failed opening migration directory: %v", err)
+	}
+	opts := []schema.MigrateOption{
+		schema.WithDir(dir),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(entDialect),
+		// Up and down files in the golang-migrate format, applied by database.NewMigrator.
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+		// Drops are written by hand: some columns and indexes, like the
+		// Postgres search vectors, only exist in the migrations.
+	}
+	if err := migrate.NamedDiff(context.Background(), *devURL, flag.Arg(0), opts...); err != nil {
+		log.Fatalf("failed generating migration: %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations generated from the
+// ent schema, one directory per database driver. New migrations are created
+// with ent/migrate/main.go; never edit a migration that has been released.
+package migrations
+
+import "embed"
+
+// FS holds the postgres and sqlite migration directories.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var FS embed.FS
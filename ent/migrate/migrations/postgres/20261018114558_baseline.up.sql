@@ -0,0 +1,2 @@
+-- create "habits" table
+CREATE TABLE "habits" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "description" character varying NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
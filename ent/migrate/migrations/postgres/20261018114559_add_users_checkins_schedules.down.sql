@@ -0,0 +1,20 @@
+-- reverse: create index "session_family" to table: "sessions"
+DROP INDEX "session_family";
+-- reverse: create index "sessions_token_hash_key" to table: "sessions"
+DROP INDEX "sessions_token_hash_key";
+-- reverse: create "sessions" table
+DROP TABLE "sessions";
+-- reverse: create index "completion_habit_id_completed_at" to table: "completions"
+DROP INDEX "completion_habit_id_completed_at";
+-- reverse: create "completions" table
+DROP TABLE "completions";
+-- reverse: modify "habits" table
+ALTER TABLE "habits" DROP CONSTRAINT "habits_users_habits", DROP COLUMN "owner_id", DROP COLUMN "rrule", DROP COLUMN "interval_days", DROP COLUMN "times_per_period", DROP COLUMN "weekdays", DROP COLUMN "schedule";
+-- reverse: create index "api_tokens_token_hash_key" to table: "api_tokens"
+DROP INDEX "api_tokens_token_hash_key";
+-- reverse: create "api_tokens" table
+DROP TABLE "api_tokens";
+-- reverse: create index "users_email_key" to table: "users"
+DROP INDEX "users_email_key";
+-- reverse: create "users" table
+DROP TABLE "users";
@@ -0,0 +1,25 @@
+-- create "users" table
+CREATE TABLE "users" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "email" character varying NOT NULL, "password_hash" character varying NOT NULL, "timezone" character varying NOT NULL DEFAULT 'UTC', "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
+-- create "api_tokens" table
+CREATE TABLE "api_tokens" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "prefix" character varying NOT NULL, "token_hash" character varying NOT NULL, "scopes" jsonb NOT NULL, "expires_at" timestamptz NULL, "last_used_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "user_id" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "api_tokens_users_api_tokens" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE);
+-- create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX "api_tokens_token_hash_key" ON "api_tokens" ("token_hash");
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "schedule" character varying NOT NULL DEFAULT 'daily', ADD COLUMN "weekdays" jsonb NULL, ADD COLUMN "times_per_period" bigint NULL, ADD COLUMN "interval_days" bigint NULL, ADD COLUMN "rrule" character varying NULL, ADD COLUMN "owner_id" bigint NULL;
+-- existing habits belong to "owner@localhost", an account without a password
+INSERT INTO "users" ("email", "password_hash", "created_at") SELECT 'owner@localhost', '', now() WHERE EXISTS (SELECT 1 FROM "habits");
+UPDATE "habits" SET "owner_id" = (SELECT "id" FROM "users" WHERE "email" = 'owner@localhost');
+-- modify "habits" table
+ALTER TABLE "habits" ALTER COLUMN "owner_id" SET NOT NULL, ADD CONSTRAINT "habits_users_habits" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+-- create "completions" table
+CREATE TABLE "completions" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "completed_at" timestamptz NOT NULL, "note" character varying NULL, "created_at" timestamptz NOT NULL, "habit_id" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "completions_habits_completions" FOREIGN KEY ("habit_id") REFERENCES "habits" ("id") ON DELETE CASCADE);
+-- create index "completion_habit_id_completed_at" to table: "completions"
+CREATE INDEX "completion_habit_id_completed_at" ON "completions" ("habit_id", "completed_at");
+-- create "sessions" table
+CREATE TABLE "sessions" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "kind" character varying NOT NULL, "token_hash" character varying NOT NULL, "family" character varying NOT NULL, "expires_at" timestamptz NOT NULL, "revoked_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "user_id" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "sessions_users_sessions" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE);
+-- create index "sessions_token_hash_key" to table: "sessions"
+CREATE UNIQUE INDEX "sessions_token_hash_key" ON "sessions" ("token_hash");
+-- create index "session_family" to table: "sessions"
+CREATE INDEX "session_family" ON "sessions" ("family");
@@ -0,0 +1,2 @@
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "tags" jsonb NULL, ADD COLUMN "archived" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,8 @@
+-- reverse: create index "completions_search_vector" to table: "completions"
+DROP INDEX "completions_search_vector";
+-- reverse: add generated full-text search column to table: "completions"
+ALTER TABLE "completions" DROP COLUMN "search_vector";
+-- reverse: create index "habits_search_vector" to table: "habits"
+DROP INDEX "habits_search_vector";
+-- reverse: add generated full-text search column to table: "habits"
+ALTER TABLE "habits" DROP COLUMN "search_vector";
@@ -0,0 +1,8 @@
+-- add generated full-text search column to table: "habits"
+ALTER TABLE "habits" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', "name"), 'A') || setweight(to_tsvector('simple', coalesce("description", '')), 'B')) STORED;
+-- create index "habits_search_vector" to table: "habits"
+CREATE INDEX "habits_search_vector" ON "habits" USING GIN ("search_vector");
+-- add generated full-text search column to table: "completions"
+ALTER TABLE "completions" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce("note", '')), 'C')) STORED;
+-- create index "completions_search_vector" to table: "completions"
+CREATE INDEX "completions_search_vector" ON "completions" USING GIN ("search_vector");
@@ -0,0 +1,2 @@
+-- reverse: modify "habits" table
+ALTER TABLE "habits" DROP COLUMN "updated_at", DROP COLUMN "version";
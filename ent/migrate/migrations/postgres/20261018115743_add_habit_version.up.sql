@@ -0,0 +1,4 @@
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "version" bigint NOT NULL DEFAULT 1, ADD COLUMN "updated_at" timestamptz NULL;
+-- backfill "updated_at" of existing habits
+UPDATE "habits" SET "updated_at" = "created_at";
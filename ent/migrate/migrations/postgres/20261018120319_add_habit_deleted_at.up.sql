@@ -0,0 +1,2 @@
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "deleted_at" timestamptz NULL;
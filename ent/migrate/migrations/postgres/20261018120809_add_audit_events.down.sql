@@ -0,0 +1,6 @@
+-- reverse: create index "auditevent_entity_type_entity_id" to table: "audit_events"
+DROP INDEX "auditevent_entity_type_entity_id";
+-- reverse: create index "auditevent_owner_id_created_at" to table: "audit_events"
+DROP INDEX "auditevent_owner_id_created_at";
+-- reverse: create "audit_events" table
+DROP TABLE "audit_events";
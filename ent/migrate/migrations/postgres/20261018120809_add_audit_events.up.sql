@@ -0,0 +1,6 @@
+-- create "audit_events" table
+CREATE TABLE "audit_events" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "created_at" timestamptz NOT NULL, "actor_id" bigint NULL, "owner_id" bigint NOT NULL, "entity_type" character varying NOT NULL, "entity_id" bigint NOT NULL, "operation" character varying NOT NULL, "before" jsonb NULL, "after" jsonb NULL, PRIMARY KEY ("id"));
+-- create index "auditevent_owner_id_created_at" to table: "audit_events"
+CREATE INDEX "auditevent_owner_id_created_at" ON "audit_events" ("owner_id", "created_at");
+-- create index "auditevent_entity_type_entity_id" to table: "audit_events"
+CREATE INDEX "auditevent_entity_type_entity_id" ON "audit_events" ("entity_type", "entity_id");
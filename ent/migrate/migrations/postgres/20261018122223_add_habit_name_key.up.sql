@@ -0,0 +1,6 @@
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "name_key" character varying NULL;
+-- "name_key" stays NULL, which the index ignores, until "migrate up" backfills it
+-- in Go: lower() does not fold case like the application on every database
+-- create index "habit_owner_id_name_key" to table: "habits"
+CREATE UNIQUE INDEX "habit_owner_id_name_key" ON "habits" ("owner_id", "name_key") WHERE deleted_at IS NULL;
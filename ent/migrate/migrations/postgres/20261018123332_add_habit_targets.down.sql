@@ -0,0 +1,4 @@
+-- reverse: modify "habits" table
+ALTER TABLE "habits" DROP COLUMN "target_direction", DROP COLUMN "target", DROP COLUMN "unit", DROP COLUMN "type";
+-- reverse: modify "completions" table
+ALTER TABLE "completions" DROP COLUMN "value";
@@ -0,0 +1,4 @@
+-- modify "completions" table
+ALTER TABLE "completions" ADD COLUMN "value" double precision NULL;
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "type" character varying NOT NULL DEFAULT 'boolean', ADD COLUMN "unit" character varying NULL, ADD COLUMN "target" double precision NULL, ADD COLUMN "target_direction" character varying NULL;
@@ -0,0 +1,2 @@
+-- modify "habits" table
+ALTER TABLE "habits" ADD COLUMN "polarity" character varying NOT NULL DEFAULT 'build';
@@ -0,0 +1,2 @@
+-- reverse: create "habits" table
+DROP TABLE `habits`;
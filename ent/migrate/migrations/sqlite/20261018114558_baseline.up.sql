@@ -0,0 +1,2 @@
+-- create "habits" table
+CREATE TABLE `habits` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `description` text NULL, `created_at` datetime NOT NULL);
@@ -0,0 +1,26 @@
+-- reverse: create index "session_family" to table: "sessions"
+DROP INDEX `session_family`;
+-- reverse: create index "sessions_token_hash_key" to table: "sessions"
+DROP INDEX `sessions_token_hash_key`;
+-- reverse: create "sessions" table
+DROP TABLE `sessions`;
+-- reverse: create index "completion_habit_id_completed_at" to table: "completions"
+DROP INDEX `completion_habit_id_completed_at`;
+-- reverse: create "completions" table
+DROP TABLE `completions`;
+-- create "new_habits" table
+CREATE TABLE `new_habits` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `description` text NULL, `created_at` datetime NOT NULL);
+-- copy rows from old table "habits" to new temporary table "new_habits"
+INSERT INTO `new_habits` (`id`, `name`, `description`, `created_at`) SELECT `id`, `name`, `description`, `created_at` FROM `habits`;
+-- drop "habits" table after copying rows
+DROP TABLE `habits`;
+-- rename temporary table "new_habits" to "habits"
+ALTER TABLE `new_habits` RENAME TO `habits`;
+-- reverse: create index "api_tokens_token_hash_key" to table: "api_tokens"
+DROP INDEX `api_tokens_token_hash_key`;
+-- reverse: create "api_tokens" table
+DROP TABLE `api_tokens`;
+-- reverse: create index "users_email_key" to table: "users"
+DROP INDEX `users_email_key`;
+-- reverse: create "users" table
+DROP TABLE `users`;
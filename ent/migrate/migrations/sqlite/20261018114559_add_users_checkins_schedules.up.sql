@@ -0,0 +1,28 @@
+-- create "users" table
+CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `email` text NOT NULL, `password_hash` text NOT NULL, `timezone` text NOT NULL DEFAULT ('UTC'), `created_at` datetime NOT NULL);
+-- create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX `users_email_key` ON `users` (`email`);
+-- create "api_tokens" table
+CREATE TABLE `api_tokens` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `prefix` text NOT NULL, `token_hash` text NOT NULL, `scopes` json NOT NULL, `expires_at` datetime NULL, `last_used_at` datetime NULL, `created_at` datetime NOT NULL, `user_id` integer NOT NULL, CONSTRAINT `api_tokens_users_api_tokens` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX `api_tokens_token_hash_key` ON `api_tokens` (`token_hash`);
+-- existing habits belong to "owner@localhost", an account without a password
+INSERT INTO `users` (`email`, `password_hash`, `created_at`) SELECT 'owner@localhost', '', CURRENT_TIMESTAMP WHERE EXISTS (SELECT 1 FROM `habits`);
+-- create "new_habits" table
+CREATE TABLE `new_habits` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `description` text NULL, `created_at` datetime NOT NULL, `schedule` text NOT NULL DEFAULT ('daily'), `weekdays` json NULL, `times_per_period` integer NULL, `interval_days` integer NULL, `rrule` text NULL, `owner_id` integer NOT NULL, CONSTRAINT `habits_users_habits` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- copy rows from old table "habits" to new temporary table "new_habits"
+INSERT INTO `new_habits` (`id`, `name`, `description`, `created_at`, `owner_id`) SELECT `id`, `name`, `description`, `created_at`, (SELECT `id` FROM `users` WHERE `email` = 'owner@localhost') FROM `habits`;
+-- drop "habits" table after copying rows
+DROP TABLE `habits`;
+-- rename temporary table "new_habits" to "habits"
+ALTER TABLE `new_habits` RENAME TO `habits`;
+-- create "completions" table
+CREATE TABLE `completions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `completed_at` datetime NOT NULL, `note` text NULL, `created_at` datetime NOT NULL, `habit_id` integer NOT NULL, CONSTRAINT `completions_habits_completions` FOREIGN KEY (`habit_id`) REFERENCES `habits` (`id`) ON DELETE CASCADE);
+-- create index "completion_habit_id_completed_at" to table: "completions"
+CREATE INDEX `completion_habit_id_completed_at` ON `completions` (`habit_id`, `completed_at`);
+-- create "sessions" table
+CREATE TABLE `sessions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `kind` text NOT NULL, `token_hash` text NOT NULL, `family` text NOT NULL, `expires_at` datetime NOT NULL, `revoked_at` datetime NULL, `created_at` datetime NOT NULL, `user_id` integer NOT NULL, CONSTRAINT `sessions_users_sessions` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- create index "sessions_token_hash_key" to table: "sessions"
+CREATE UNIQUE INDEX `sessions_token_hash_key` ON `sessions` (`token_hash`);
+-- create index "session_family" to table: "sessions"
+CREATE INDEX `session_family` ON `sessions` (`family`);
@@ -0,0 +1,4 @@
+-- reverse: add column "archived" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `archived`;
+-- reverse: add column "tags" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `tags`;
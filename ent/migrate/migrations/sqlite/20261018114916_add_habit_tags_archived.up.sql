@@ -0,0 +1,4 @@
+-- add column "tags" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `tags` json NULL;
+-- add column "archived" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `archived` bool NOT NULL DEFAULT (false);
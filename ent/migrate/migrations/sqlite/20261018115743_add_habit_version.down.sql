@@ -0,0 +1,4 @@
+-- reverse: add column "updated_at" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `updated_at`;
+-- reverse: add column "version" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `version`;
@@ -0,0 +1,6 @@
+-- add column "version" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `version` integer NOT NULL DEFAULT (1);
+-- add column "updated_at" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `updated_at` datetime NULL;
+-- backfill "updated_at" of existing habits
+UPDATE `habits` SET `updated_at` = `created_at`;
@@ -0,0 +1,2 @@
+-- reverse: add column "deleted_at" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `deleted_at`;
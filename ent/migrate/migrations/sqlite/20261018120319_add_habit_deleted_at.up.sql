@@ -0,0 +1,2 @@
+-- add column "deleted_at" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `deleted_at` datetime NULL;
@@ -0,0 +1,6 @@
+-- create "audit_events" table
+CREATE TABLE `audit_events` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `created_at` datetime NOT NULL, `actor_id` integer NULL, `owner_id` integer NOT NULL, `entity_type` text NOT NULL, `entity_id` integer NOT NULL, `operation` text NOT NULL, `before` json NULL, `after` json NULL);
+-- create index "auditevent_owner_id_created_at" to table: "audit_events"
+CREATE INDEX `auditevent_owner_id_created_at` ON `audit_events` (`owner_id`, `created_at`);
+-- create index "auditevent_entity_type_entity_id" to table: "audit_events"
+CREATE INDEX `auditevent_entity_type_entity_id` ON `audit_events` (`entity_type`, `entity_id`);
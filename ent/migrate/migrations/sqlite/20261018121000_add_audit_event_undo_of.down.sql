@@ -0,0 +1,4 @@
+-- reverse: create index "auditevent_undo_of" to table: "audit_events"
+DROP INDEX `auditevent_undo_of`;
+-- reverse: add column "undo_of" to table: "audit_events"
+ALTER TABLE `audit_events` DROP COLUMN `undo_of`;
@@ -0,0 +1,4 @@
+-- add column "undo_of" to table: "audit_events"
+ALTER TABLE `audit_events` ADD COLUMN `undo_of` integer NULL;
+-- create index "auditevent_undo_of" to table: "audit_events"
+CREATE UNIQUE INDEX `auditevent_undo_of` ON `audit_events` (`undo_of`);
@@ -0,0 +1,2 @@
+-- reverse: add column "color" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `color`;
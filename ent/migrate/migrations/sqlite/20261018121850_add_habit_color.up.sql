@@ -0,0 +1,2 @@
+-- add column "color" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `color` text NULL;
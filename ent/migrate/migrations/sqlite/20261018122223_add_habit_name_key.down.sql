@@ -0,0 +1,4 @@
+-- reverse: create index "habit_owner_id_name_key" to table: "habits"
+DROP INDEX `habit_owner_id_name_key`;
+-- reverse: add column "name_key" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `name_key`;
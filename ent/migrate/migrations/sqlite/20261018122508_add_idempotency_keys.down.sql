@@ -0,0 +1,6 @@
+-- reverse: create index "idempotencykey_created_at" to table: "idempotency_keys"
+DROP INDEX `idempotencykey_created_at`;
+-- reverse: create index "idempotencykey_user_id_key" to table: "idempotency_keys"
+DROP INDEX `idempotencykey_user_id_key`;
+-- reverse: create "idempotency_keys" table
+DROP TABLE `idempotency_keys`;
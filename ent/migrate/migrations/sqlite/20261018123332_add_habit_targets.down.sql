@@ -0,0 +1,7 @@
+-- reverse: add columns "type", "unit", "target" and "target_direction" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `target_direction`;
+ALTER TABLE `habits` DROP COLUMN `target`;
+ALTER TABLE `habits` DROP COLUMN `unit`;
+ALTER TABLE `habits` DROP COLUMN `type`;
+-- reverse: add column "value" to table: "completions"
+ALTER TABLE `completions` DROP COLUMN `value`;
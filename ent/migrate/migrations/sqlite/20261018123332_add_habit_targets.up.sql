@@ -0,0 +1,7 @@
+-- add column "value" to table: "completions"
+ALTER TABLE `completions` ADD COLUMN `value` real NULL;
+-- add columns "type", "unit", "target" and "target_direction" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `type` text NOT NULL DEFAULT ('boolean');
+ALTER TABLE `habits` ADD COLUMN `unit` text NULL;
+ALTER TABLE `habits` ADD COLUMN `target` real NULL;
+ALTER TABLE `habits` ADD COLUMN `target_direction` text NULL;
@@ -0,0 +1,2 @@
+-- reverse: add column "polarity" to table: "habits"
+ALTER TABLE `habits` DROP COLUMN `polarity`;
@@ -0,0 +1,2 @@
+-- add column "polarity" to table: "habits"
+ALTER TABLE `habits` ADD COLUMN `polarity` text NOT NULL DEFAULT ('build');
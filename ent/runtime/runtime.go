@@ -2,7 +2,129 @@
 
 package runtime
 
-// The schema-stitching logic is generated in api/ent/runtime.go
+import (
+	"api/ent/apitoken"
+	"api/ent/auditevent"
+	"api/ent/completion"
+	"api/ent/habit"
+	"api/ent/idempotencykey"
+	"api/ent/schema"
+	"api/ent/session"
+	"api/ent/user"
+	"time"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	apitokenFields := schema.APIToken{}.Fields()
+	_ = apitokenFields
+	// apitokenDescName is the schema descriptor for name field.
+	apitokenDescName := apitokenFields[1].Descriptor()
+	// apitoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apitoken.NameValidator = apitokenDescName.Validators[0].(func(string) error)
+	// apitokenDescCreatedAt is the schema descriptor for created_at field.
+	apitokenDescCreatedAt := apitokenFields[7].Descriptor()
+	// apitoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apitoken.DefaultCreatedAt = apitokenDescCreatedAt.Default.(func() time.Time)
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[0].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	completionFields := schema.Completion{}.Fields()
+	_ = completionFields
+	// completionDescCompletedAt is the schema descriptor for completed_at field.
+	completionDescCompletedAt := completionFields[1].Descriptor()
+	// completion.DefaultCompletedAt holds the default value on creation for the completed_at field.
+	completion.DefaultCompletedAt = completionDescCompletedAt.Default.(func() time.Time)
+	// completionDescValue is the schema descriptor for value field.
+	completionDescValue := completionFields[3].Descriptor()
+	// completion.ValueValidator is a validator for the "value" field. It is called by the builders before save.
+	completion.ValueValidator = completionDescValue.Validators[0].(func(float64) error)
+	// completionDescCreatedAt is the schema descriptor for created_at field.
+	completionDescCreatedAt := completionFields[4].Descriptor()
+	// completion.DefaultCreatedAt holds the default value on creation for the created_at field.
+	completion.DefaultCreatedAt = completionDescCreatedAt.Default.(func() time.Time)
+	habitHooks := schema.Habit{}.Hooks()
+	habit.Hooks[0] = habitHooks[0]
+	habit.Hooks[1] = habitHooks[1]
+	habit.Hooks[2] = habitHooks[2]
+	habitInters := schema.Habit{}.Interceptors()
+	habit.Interceptors[0] = habitInters[0]
+	habitFields := schema.Habit{}.Fields()
+	_ = habitFields
+	// habitDescName is the schema descriptor for name field.
+	habitDescName := habitFields[0].Descriptor()
+	// habit.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	habit.NameValidator = habitDescName.Validators[0].(func(string) error)
+	// habitDescColor is the schema descriptor for color field.
+	habitDescColor := habitFields[3].Descriptor()
+	// habit.ColorValidator is a validator for the "color" field. It is called by the builders before save.
+	habit.ColorValidator = habitDescColor.Validators[0].(func(string) error)
+	// habitDescCreatedAt is the schema descriptor for created_at field.
+	habitDescCreatedAt := habitFields[4].Descriptor()
+	// habit.DefaultCreatedAt holds the default value on creation for the created_at field.
+	habit.DefaultCreatedAt = habitDescCreatedAt.Default.(func() time.Time)
+	// habitDescTimesPerPeriod is the schema descriptor for times_per_period field.
+	habitDescTimesPerPeriod := habitFields[8].Descriptor()
+	// habit.TimesPerPeriodValidator is a validator for the "times_per_period" field. It is called by the builders before save.
+	habit.TimesPerPeriodValidator = habitDescTimesPerPeriod.Validators[0].(func(int) error)
+	// habitDescIntervalDays is the schema descriptor for interval_days field.
+	habitDescIntervalDays := habitFields[9].Descriptor()
+	// habit.IntervalDaysValidator is a validator for the "interval_days" field. It is called by the builders before save.
+	habit.IntervalDaysValidator = habitDescIntervalDays.Validators[0].(func(int) error)
+	// habitDescTarget is the schema descriptor for target field.
+	habitDescTarget := habitFields[14].Descriptor()
+	// habit.TargetValidator is a validator for the "target" field. It is called by the builders before save.
+	habit.TargetValidator = habitDescTarget.Validators[0].(func(float64) error)
+	// habitDescArchived is the schema descriptor for archived field.
+	habitDescArchived := habitFields[17].Descriptor()
+	// habit.DefaultArchived holds the default value on creation for the archived field.
+	habit.DefaultArchived = habitDescArchived.Default.(bool)
+	// habitDescVersion is the schema descriptor for version field.
+	habitDescVersion := habitFields[18].Descriptor()
+	// habit.DefaultVersion holds the default value on creation for the version field.
+	habit.DefaultVersion = habitDescVersion.Default.(int)
+	// habitDescUpdatedAt is the schema descriptor for updated_at field.
+	habitDescUpdatedAt := habitFields[19].Descriptor()
+	// habit.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	habit.DefaultUpdatedAt = habitDescUpdatedAt.Default.(func() time.Time)
+	// habit.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	habit.UpdateDefaultUpdatedAt = habitDescUpdatedAt.UpdateDefault.(func() time.Time)
+	idempotencykeyFields := schema.IdempotencyKey{}.Fields()
+	_ = idempotencykeyFields
+	// idempotencykeyDescKey is the schema descriptor for key field.
+	idempotencykeyDescKey := idempotencykeyFields[1].Descriptor()
+	// idempotencykey.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	idempotencykey.KeyValidator = idempotencykeyDescKey.Validators[0].(func(string) error)
+	// idempotencykeyDescCreatedAt is the schema descriptor for created_at field.
+	idempotencykeyDescCreatedAt := idempotencykeyFields[6].Descriptor()
+	// idempotencykey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	idempotencykey.DefaultCreatedAt = idempotencykeyDescCreatedAt.Default.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[6].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[0].Descriptor()
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
+	// userDescTimezone is the schema descriptor for timezone field.
+	userDescTimezone := userFields[2].Descriptor()
+	// user.DefaultTimezone holds the default value on creation for the timezone field.
+	user.DefaultTimezone = userDescTimezone.Default.(string)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userFields[3].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+}
 
 const (
 	Version = "v0.14.4"                                         // Version of ent codegen.
@@ -0,0 +1,38 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "time"
+)
+
+// APIToken is a personal access token for scripts and integrations. Only
+// the SHA-256 of the secret is stored; the prefix identifies the token in
+// listings.
+type APIToken struct {
+    ent.Schema
+}
+
+func (APIToken) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("user_id"),
+        field.String("name").NotEmpty(),
+        field.String("prefix"),
+        field.String("token_hash").Unique().Sensitive(),
+        field.Strings("scopes"),
+        field.Time("expires_at").Optional().Nillable(),
+        field.Time("last_used_at").Optional().Nillable(),
+        field.Time("created_at").Default(time.Now).Immutable(),
+    }
+}
+
+func (APIToken) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("api_tokens").
+            Field("user_id").
+            Unique().
+            Required(),
+    }
+}
@@ -0,0 +1,44 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+    "time"
+)
+
+// AuditEvent records a change to a habit or check-in. Events are written by
+// the audit hook and never changed afterwards.
+type AuditEvent struct {
+    ent.Schema
+}
+
+func (AuditEvent) Fields() []ent.Field {
+    return []ent.Field{
+        field.Time("created_at").Default(time.Now).Immutable(),
+        // User who made the change, unset for changes made by the server
+        // itself such as purging the trash.
+        field.Int("actor_id").Optional().Nillable().Immutable(),
+        // User owning the changed entity; only they can see the event.
+        field.Int("owner_id").Immutable(),
+        // Entity type as named by the API: habit or checkin.
+        field.String("entity_type").Immutable(),
+        field.Int("entity_id").Immutable(),
+        field.Enum("operation").Values("create", "update", "delete").Immutable(),
+        // Values of the changed fields before and after the change. A
+        // create has no before, a delete that removed the row no after.
+        field.JSON("before", map[string]any{}).Optional().Immutable(),
+        field.JSON("after", map[string]any{}).Optional().Immutable(),
+        // Event reverted by this change, set for changes made by an undo.
+        field.Int("undo_of").Optional().Nillable().Immutable(),
+    }
+}
+
+func (AuditEvent) Indexes() []ent.Index {
+    return []ent.Index{
+        index.Fields("owner_id", "created_at"),
+        index.Fields("entity_type", "entity_id"),
+        // An event is undone at most once, even by concurrent requests.
+        index.Fields("undo_of").Unique(),
+    }
+}
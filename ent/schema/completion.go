@@ -0,0 +1,42 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+    "time"
+)
+
+// Completion is a single check-in recording that a habit was done.
+type Completion struct {
+    ent.Schema
+}
+
+func (Completion) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("habit_id"),
+        field.Time("completed_at").Default(time.Now),
+        field.String("note").Optional(),
+        // Amount done, for numeric and duration habits.
+        field.Float("value").Optional().Nillable().Min(0),
+        field.Time("created_at").Default(time.Now).Immutable(),
+    }
+}
+
+func (Completion) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("habit", Habit.Type).
+            Ref("completions").
+            Field("habit_id").
+            Unique().
+            Required(),
+    }
+}
+
+func (Completion) Indexes() []ent.Index {
+    return []ent.Index{
+        // Date-range lookups always filter by habit first.
+        index.Fields("habit_id", "completed_at"),
+    }
+}
@@ -1,9 +1,22 @@
 package schema
 
 import (
+    "context"
+    "fmt"
+    "regexp"
+    "strings"
+    "time"
+
+    gen "api/ent"
+    "api/ent/habit"
+    "api/ent/hook"
+    "api/ent/intercept"
+
     "entgo.io/ent"
+    "entgo.io/ent/dialect/entsql"
+    "entgo.io/ent/schema/edge"
     "entgo.io/ent/schema/field"
-    "time"
+    "entgo.io/ent/schema/index"
 )
 
 type Habit struct {
@@ -13,7 +26,159 @@ type Habit struct {
 func (Habit) Fields() []ent.Field {
     return []ent.Field{
         field.String("name").NotEmpty(),
+        // Lowercased name, set by a hook, to keep names unique per owner
+        // ignoring case. The column is nullable so it could be added to
+        // existing tables.
+        field.String("name_key").Optional().StructTag(`json:"-"`),
         field.String("description").Optional(),
+        // Color the UI shows the habit in, as "#rrggbb".
+        field.String("color").Optional().Match(regexp.MustCompile(`^#[0-9a-f]{6}$`)),
         field.Time("created_at").Default(time.Now),
+        field.Int("owner_id").Immutable(),
+        // Recurrence. Only the fields matching the schedule kind are set:
+        // weekdays (0 = Sunday) for "weekdays", times_per_period for
+        // "weekly"/"monthly", interval_days for "interval" and an RFC 5545
+        // rule for "rrule".
+        field.Enum("schedule").
+            Values("daily", "weekdays", "weekly", "monthly", "interval", "rrule").
+            Default("daily"),
+        field.Ints("weekdays").Optional(),
+        field.Int("times_per_period").Optional().Positive(),
+        field.Int("interval_days").Optional().Positive(),
+        field.String("rrule").Optional(),
+        // Habits to build are checked in when done. Check-ins of habits to
+        // quit record slips, so they are tracked by the clean days between
+        // them instead of by streaks.
+        field.Enum("polarity").
+            Values("build", "quit").
+            Default("build"),
+        // Measurement. Boolean habits are done by checking in, numeric habits
+        // count values in unit and duration habits minutes. A measured habit
+        // is done on a day once the values checked in that day add up to at
+        // least, or at most, the target.
+        field.Enum("type").
+            Values("boolean", "numeric", "duration").
+            Default("boolean"),
+        field.String("unit").Optional(),
+        field.Float("target").Optional().Nillable().Min(0),
+        field.Enum("target_direction").
+            Values("at_least", "at_most").
+            Optional(),
+        // Free-form labels used to filter the habit list.
+        field.Strings("tags").Optional(),
+        // Archived habits are hidden from the habit list unless asked for.
+        field.Bool("archived").Default(false),
+        // Version is bumped on every update and served as the ETag, so
+        // concurrent edits can be detected with If-Match.
+        field.Int("version").Default(1),
+        // Always set by ent; the column is nullable so it could be added to
+        // existing tables.
+        field.Time("updated_at").Optional().Default(time.Now).UpdateDefault(time.Now),
+        // Set while the habit is in the trash. Deleted habits are hidden
+        // from every query unless SkipSoftDelete is used.
+        field.Time("deleted_at").Optional().Nillable(),
+    }
+}
+
+func (Habit) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("owner", User.Type).
+            Ref("habits").
+            Field("owner_id").
+            Unique().
+            Required().
+            Immutable(),
+        // Check-ins are owned by the habit and go away with it.
+        edge.To("completions", Completion.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+    }
+}
+
+func (Habit) Indexes() []ent.Index {
+    return []ent.Index{
+        // Habits in the trash do not block their names.
+        index.Fields("owner_id", "name_key").
+            Unique().
+            Annotations(entsql.IndexWhere("deleted_at IS NULL")),
     }
 }
+
+func (Habit) Hooks() []ent.Hook {
+    return []ent.Hook{
+        hook.On(
+            func(next ent.Mutator) ent.Mutator {
+                return hook.HabitFunc(func(ctx context.Context, m *gen.HabitMutation) (ent.Value, error) {
+                    if name, ok := m.Name(); ok {
+                        m.SetNameKey(HabitNameKey(name))
+                    }
+                    return next.Mutate(ctx, m)
+                })
+            },
+            ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+        ),
+        hook.On(
+            func(next ent.Mutator) ent.Mutator {
+                return hook.HabitFunc(func(ctx context.Context, m *gen.HabitMutation) (ent.Value, error) {
+                    if _, set := m.Version(); !set {
+                        m.AddVersion(1)
+                    }
+                    return next.Mutate(ctx, m)
+                })
+            },
+            ent.OpUpdate|ent.OpUpdateOne,
+        ),
+        // Deleting moves habits to the trash, and habits in the trash
+        // cannot be changed until they are restored.
+        hook.On(
+            func(next ent.Mutator) ent.Mutator {
+                return hook.HabitFunc(func(ctx context.Context, m *gen.HabitMutation) (ent.Value, error) {
+                    if skipSoftDelete(ctx) {
+                        return next.Mutate(ctx, m)
+                    }
+                    m.Where(habit.DeletedAtIsNil())
+                    if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+                        return next.Mutate(ctx, m)
+                    }
+                    m.SetOp(ent.OpUpdate)
+                    m.SetDeletedAt(time.Now())
+                    v, err := m.Client().Mutate(ctx, m)
+                    if err != nil {
+                        return nil, fmt.Errorf("moving habit to trash: %w", err)
+                    }
+                    return v, nil
+                })
+            },
+            ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+        ),
+    }
+}
+
+func (Habit) Interceptors() []ent.Interceptor {
+    return []ent.Interceptor{
+        intercept.TraverseHabit(func(ctx context.Context, q *gen.HabitQuery) error {
+            if !skipSoftDelete(ctx) {
+                q.Where(habit.DeletedAtIsNil())
+            }
+            return nil
+        }),
+    }
+}
+
+// HabitNameKey is the form of a habit name that must be unique among the
+// habits of an owner.
+func HabitNameKey(name string) string {
+    return strings.ToLower(name)
+}
+
+type softDeleteKey struct{}
+
+// SkipSoftDelete returns a context in which habit queries include the
+// trash and deleting a habit removes it for good.
+func SkipSoftDelete(parent context.Context) context.Context {
+    return context.WithValue(parent, softDeleteKey{}, true)
+}
+
+func skipSoftDelete(ctx context.Context) bool {
+    skip, _ := ctx.Value(softDeleteKey{}).(bool)
+    return skip
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+    "time"
+)
+
+// IdempotencyKey remembers a POST request sent with an Idempotency-Key
+// header and the response it got, so that a retry gets the same response
+// instead of repeating the request.
+type IdempotencyKey struct {
+    ent.Schema
+}
+
+func (IdempotencyKey) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("user_id").Immutable(),
+        field.String("key").NotEmpty().Immutable(),
+        // SHA-256 of the method, path, query and body, to recognize a key reused
+        // for another request.
+        field.String("request_hash").Immutable(),
+        // Unset while the request is being handled.
+        field.Int("status").Optional(),
+        // Response headers worth replaying, like Content-Type and ETag.
+        field.JSON("header", map[string]string{}).Optional(),
+        field.Bytes("body").Optional(),
+        field.Time("created_at").Default(time.Now).Immutable(),
+    }
+}
+
+func (IdempotencyKey) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("idempotency_keys").
+            Field("user_id").
+            Unique().
+            Required().
+            Immutable(),
+    }
+}
+
+func (IdempotencyKey) Indexes() []ent.Index {
+    return []ent.Index{
+        index.Fields("user_id", "key").Unique(),
+        index.Fields("created_at"),
+    }
+}
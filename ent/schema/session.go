@@ -0,0 +1,46 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "entgo.io/ent/schema/index"
+    "time"
+)
+
+// Session is a login of a user, either a refresh token handed out with JWT
+// access tokens or the cookie session of the HTML page. Only the SHA-256 of
+// the token is stored.
+type Session struct {
+    ent.Schema
+}
+
+func (Session) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("user_id"),
+        field.Enum("kind").Values("refresh", "cookie"),
+        field.String("token_hash").Unique().Sensitive(),
+        // Refresh tokens rotated from the same login share a family, so that
+        // the whole chain can be revoked when a used token is replayed.
+        field.String("family"),
+        field.Time("expires_at"),
+        field.Time("revoked_at").Optional().Nillable(),
+        field.Time("created_at").Default(time.Now).Immutable(),
+    }
+}
+
+func (Session) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("sessions").
+            Field("user_id").
+            Unique().
+            Required(),
+    }
+}
+
+func (Session) Indexes() []ent.Index {
+    return []ent.Index{
+        index.Fields("family"),
+    }
+}
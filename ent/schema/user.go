@@ -0,0 +1,37 @@
+package schema
+
+import (
+    "entgo.io/ent"
+    "entgo.io/ent/dialect/entsql"
+    "entgo.io/ent/schema/edge"
+    "entgo.io/ent/schema/field"
+    "time"
+)
+
+// User is an account owning habits.
+type User struct {
+    ent.Schema
+}
+
+func (User) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("email").NotEmpty().Unique(),
+        field.String("password_hash").Sensitive(),
+        // IANA time zone used to evaluate days for streaks and schedules.
+        field.String("timezone").Default("UTC"),
+        field.Time("created_at").Default(time.Now).Immutable(),
+    }
+}
+
+func (User) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.To("habits", Habit.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+        edge.To("sessions", Session.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+        edge.To("api_tokens", APIToken.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+        edge.To("idempotency_keys", IdempotencyKey.Type).
+            Annotations(entsql.OnDelete(entsql.Cascade)),
+    }
+}
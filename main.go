@@ -2,174 +2,128 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
 
-	"api/ent"
-	"api/ent/habit"
+	"api/config"
+	"api/database"
+	"api/server"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/lib/pq"
 
 	// Swagger
 	_ "api/docs" // This is required for swagger docs (after swag init)
-
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-var client *ent.Client
-var ctx context.Context
-
 // @title Habit Tracker API
 // @version 1.0
 // @description This is an API for a habit tracker app.
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token from /auth/login or personal API token from /tokens, sent as "Bearer <token>".
 
 func main() {
-	var err error
-	client, err = ent.Open("postgres", "postgres://hire:me@localhost:5432/hireme?sslmode=disable")
-	if err != nil {
-		log.Fatalf("failed opening connection to postgres: %v", err)
-	}
-	defer client.Close()
-	ctx = context.Background()
-	if err := client.Schema.Create(ctx); err != nil {
-		log.Fatalf("failed creating schema resources: %v", err)
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [migrate up|down|status|force VERSION]\n", os.Args[0])
+		flag.PrintDefaults()
 	}
-
-	r := gin.Default()
-	r.LoadHTMLGlob("templates/*")
-
-	// Swagger UI endpoint
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	// Register routes with named handlers
-	r.GET("/", func(c *gin.Context) {
-		c.HTML(http.StatusOK, "index.html", nil)
-	})
-	r.GET("/habits", GetHabits)
-	r.POST("/habits", CreateHabit)
-	r.GET("/habits/:id", GetHabit)
-	r.PUT("/habits/:id", UpdateHabit)
-	r.DELETE("/habits/:id", DeleteHabit)
-
-	r.Run(":8080")
-}
-
-// @Summary Get all habits
-// @Produce json
-// @Success 200 {array} ent.Habit
-// @Router /habits [get]
-func GetHabits(c *gin.Context) {
-	habits, err := client.Habit.Query().Order(ent.Desc(habit.FieldCreatedAt)).All(ctx)
+	flag.Parse()
+	cfg, err := config.Load(*configFile)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		log.Fatalf("failed loading configuration: %v", err)
+	}
+	level, _ := cfg.SlogLevel()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	switch flag.Arg(0) {
+	case "":
+	case "migrate":
+		if err := runMigrate(cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
 		return
+	default:
+		flag.Usage()
+		os.Exit(2)
 	}
-	c.JSON(http.StatusOK, habits)
-}
 
-// @Summary Get a single habit
-// @Produce json
-// @Param id path int true "Habit ID"
-// @Success 200 {object} ent.Habit
-// @Router /habits/{id} [get]
-func GetHabit(c *gin.Context) {
-	id, err := toInt(c.Param("id"))
+	client, err := database.Open(cfg.DatabaseDriver, cfg.DatabaseURL)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid habit ID"})
-		return
+		log.Fatalf("failed opening connection to %s: %v", cfg.DatabaseDriver, err)
 	}
-	h, err := client.Habit.Get(ctx, id)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Habit not found"})
-		return
+	defer client.Close()
+	if database.InMemory(cfg.DatabaseDriver, cfg.DatabaseURL) {
+		// Nothing to migrate in a database that starts empty
+		if err := client.Schema.Create(context.Background()); err != nil {
+			log.Fatalf("failed creating schema resources: %v", err)
+		}
+	} else if err := database.CheckSchema(cfg.DatabaseDriver, cfg.DatabaseURL); err != nil {
+		log.Fatalf("refusing to start: %v", err)
 	}
-	c.JSON(http.StatusOK, h)
-}
 
-// @Summary Create a new habit
-// @Accept json
-// @Produce json
-// @Param habit body ent.Habit true "Habit to create"
-// @Success 200 {object} ent.Habit
-// @Router /habits [post]
-func CreateHabit(c *gin.Context) {
-	var newHabit ent.Habit
-	if err := c.ShouldBindJSON(&newHabit); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	if newHabit.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Name cannot be empty"})
-		return
-	}
-	h, err := client.Habit.Create().
-		SetName(newHabit.Name).
-		SetDescription(newHabit.Description).
-		Save(ctx)
+	gin.SetMode(cfg.GinMode)
+	srv, err := server.New(client, cfg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		log.Fatalf("failed creating server: %v", err)
 	}
-	c.JSON(http.StatusOK, h)
+	go srv.RunPurge(context.Background())
+	slog.Info("listening", "addr", cfg.ListenAddr)
+	log.Fatal(http.ListenAndServe(cfg.ListenAddr, srv))
 }
 
-// @Summary Update a habit
-// @Accept json
-// @Produce json
-// @Param id path int true "Habit ID"
-// @Param habit body ent.Habit true "Habit to update"
-// @Success 200 {object} ent.Habit
-// @Router /habits/{id} [put]
-func UpdateHabit(c *gin.Context) {
-	id, err := toInt(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid habit ID"})
-		return
+// runMigrate implements the migrate subcommand.
+func runMigrate(cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command, use up, down, status or force VERSION")
 	}
-	var updatedHabit ent.Habit
-	if err := c.ShouldBindJSON(&updatedHabit); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	mg, err := database.NewMigrator(cfg.DatabaseDriver, cfg.DatabaseURL)
+	if err != nil {
+		return err
 	}
-	if updatedHabit.Name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Name cannot be empty"})
-		return
+	defer mg.Close()
+
+	switch args[0] {
+	case "up":
+		err = mg.Up()
+	case "down":
+		err = mg.Down()
+	case "force":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: migrate force VERSION")
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		err = mg.Force(version)
+	case "status":
+	default:
+		return fmt.Errorf("unknown command %q, use up, down, status or force VERSION", args[0])
 	}
-	h, err := client.Habit.UpdateOneID(id).
-		SetName(updatedHabit.Name).
-		SetDescription(updatedHabit.Description).
-		Save(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return err
 	}
-	c.JSON(http.StatusOK, h)
-}
 
-// @Summary Delete a habit
-// @Produce json
-// @Param id path int true "Habit ID"
-// @Success 200 {object} object
-// @Router /habits/{id} [delete]
-func DeleteHabit(c *gin.Context) {
-	id, err := toInt(c.Param("id"))
+	st, err := mg.Status()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid habit ID"})
-		return
+		return err
 	}
-	err = client.Habit.DeleteOneID(id).Exec(ctx)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	fmt.Printf("version: %d\n", st.Current)
+	fmt.Printf("latest:  %d\n", st.Latest)
+	if st.Dirty {
+		fmt.Println("dirty:   true")
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Habit deleted successfully"})
-}
-
-func toInt(s string) (int, error) {
-	return strconv.Atoi(s)
+	for _, v := range st.Pending {
+		fmt.Printf("pending: %d\n", v)
+	}
+	return nil
 }
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/auditevent"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List audit events
+// @Description Changes to the user's habits and check-ins, newest first. Each event holds the values of the changed fields before and after the change.
+// @Produce json
+// @Param entity_type query string false "Only events of this entity type" Enums(habit, checkin)
+// @Param entity_id query int false "Only events of this entity"
+// @Param actor_id query int false "Only changes made by this user"
+// @Param limit query int false "Page size, 1 to 100" default(50)
+// @Param cursor query string false "Opaque cursor taken from the Link header of the previous page"
+// @Success 200 {array} ent.AuditEvent
+// @Header 200 {string} Link "Next page, if any"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Security BearerAuth
+// @Router /audit [get]
+func (srv *Server) GetAuditEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := srv.client.AuditEvent.Query().
+		Where(auditevent.OwnerID(currentUser(c).ID))
+	switch t := c.Query("entity_type"); t {
+	case "":
+	case audit.EntityHabit, audit.EntityCheckin:
+		query.Where(auditevent.EntityType(t))
+	default:
+		c.Error(invalidParam("Invalid entity_type, use habit or checkin"))
+		return
+	}
+	if v := c.Query("entity_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.Error(invalidParam("Invalid entity_id"))
+			return
+		}
+		query.Where(auditevent.EntityID(id))
+	}
+	if v := c.Query("actor_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.Error(invalidParam("Invalid actor_id"))
+			return
+		}
+		query.Where(auditevent.ActorID(id))
+	}
+	limit := defaultPageSize
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			c.Error(invalidParam("Invalid limit, use 1 to 100"))
+			return
+		}
+		limit = n
+	}
+	if v := c.Query("cursor"); v != "" {
+		// Events are never changed, so the last ID is enough to continue
+		b, err := base64.RawURLEncoding.DecodeString(v)
+		id, convErr := strconv.Atoi(string(b))
+		if err != nil || convErr != nil {
+			c.Error(invalidParam("Invalid cursor"))
+			return
+		}
+		query.Where(auditevent.IDLT(id))
+	}
+
+	events, err := query.
+		Order(ent.Desc(auditevent.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if len(events) > limit {
+		events = events[:limit]
+		last := strconv.Itoa(events[limit-1].ID)
+		setNextLink(c, base64.RawURLEncoding.EncodeToString([]byte(last)))
+	}
+	c.JSON(http.StatusOK, events)
+}
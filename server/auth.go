@@ -0,0 +1,467 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/session"
+	"api/ent/user"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// refreshTokenTTL is how long a refresh token can be exchanged.
+	refreshTokenTTL = 30 * 24 * time.Hour
+	// cookieSessionTTL is how long the HTML page stays logged in.
+	cookieSessionTTL = 30 * 24 * time.Hour
+	// sessionCookie is the name of the cookie holding the session token.
+	sessionCookie = "session"
+)
+
+// errUnauthenticated is returned by authenticate when the request carries
+// no valid credentials.
+var errUnauthenticated = errors.New("unauthenticated")
+
+// Credentials is the request body of the register and login endpoints.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	// Timezone is only used on registration and defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// RefreshRequest carries a refresh token to rotate or revoke.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse is an OAuth 2 style token pair.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// @Summary Register a new user
+// @Accept json
+// @Produce json
+// @Param credentials body Credentials true "Email, password (at least 8 characters) and optional time zone"
+// @Success 200 {object} ent.User
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /auth/register [post]
+func (srv *Server) Register(c *gin.Context) {
+	ctx := c.Request.Context()
+	var creds Credentials
+	if !bindJSON(c, &creds) {
+		return
+	}
+	email := strings.ToLower(strings.TrimSpace(creds.Email))
+	if !strings.Contains(email, "@") {
+		c.Error(invalidField("email", "must be an email address"))
+		return
+	}
+	if len(creds.Password) < 8 {
+		c.Error(invalidField("password", "must be at least 8 characters"))
+		return
+	}
+	create := srv.client.User.Create().SetEmail(email)
+	if creds.Timezone != "" {
+		if _, err := time.LoadLocation(creds.Timezone); err != nil {
+			c.Error(invalidField("timezone", "must be an IANA time zone"))
+			return
+		}
+		create.SetTimezone(creds.Timezone)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	u, err := create.SetPasswordHash(string(hash)).Save(ctx)
+	if ent.IsConstraintError(err) {
+		c.Error(problem(http.StatusConflict, codeConflict, "Email already registered"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, u)
+}
+
+// @Summary Log in and obtain a JWT access token and a refresh token
+// @Accept json
+// @Produce json
+// @Param credentials body Credentials true "Email and password"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /auth/login [post]
+func (srv *Server) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+	u, ok := srv.checkCredentials(c)
+	if !ok {
+		return
+	}
+	family, err := newToken()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resp, err := srv.issueTokens(ctx, u.ID, family)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Exchange a refresh token for a new token pair
+// @Description The refresh token is rotated: it can only be used once, and replaying a used token revokes every token issued from the same login.
+// @Accept json
+// @Produce json
+// @Param refresh body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /auth/refresh [post]
+func (srv *Server) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req RefreshRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	s, err := srv.client.Session.Query().
+		Where(
+			session.TokenHash(hashToken(req.RefreshToken)),
+			session.KindEQ(session.KindRefresh),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(problem(http.StatusUnauthorized, codeUnauthenticated, "Invalid or expired refresh token"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	// Revoking only succeeds for the first use of the token. Anything else
+	// is a replay, so the whole family is considered compromised.
+	n, err := srv.client.Session.Update().
+		Where(session.ID(s.ID), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if n == 0 {
+		if err := srv.revokeFamily(ctx, s.Family); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Error(problem(http.StatusUnauthorized, codeUnauthenticated, "Refresh token reuse detected"))
+		return
+	}
+	resp, err := srv.issueTokens(ctx, s.UserID, s.Family)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Revoke a refresh token and every token rotated from the same login
+// @Accept json
+// @Produce json
+// @Param refresh body RefreshRequest true "Refresh token"
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /auth/logout [post]
+func (srv *Server) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req RefreshRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	s, err := srv.client.Session.Query().
+		Where(session.TokenHash(hashToken(req.RefreshToken)), session.KindEQ(session.KindRefresh)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(problem(http.StatusUnauthorized, codeUnauthenticated, "Invalid refresh token"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := srv.revokeFamily(ctx, s.Family); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// @Summary Log in the HTML page with a session cookie
+// @Accept json
+// @Produce json
+// @Param credentials body Credentials true "Email and password"
+// @Success 200 {object} ent.User
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /auth/session [post]
+func (srv *Server) CreateCookieSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	u, ok := srv.checkCredentials(c)
+	if !ok {
+		return
+	}
+	token, err := newToken()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	family, err := newToken()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	_, err = srv.client.Session.Create().
+		SetUserID(u.ID).
+		SetKind(session.KindCookie).
+		SetTokenHash(hashToken(token)).
+		SetFamily(family).
+		SetExpiresAt(time.Now().Add(cookieSessionTTL)).
+		Save(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	setSessionCookie(c, token, int(cookieSessionTTL.Seconds()))
+	c.JSON(http.StatusOK, u)
+}
+
+// @Summary Log out the HTML page
+// @Produce json
+// @Success 200 {object} object
+// @Router /auth/session [delete]
+func (srv *Server) DeleteCookieSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	if token, err := c.Cookie(sessionCookie); err == nil {
+		_, err := srv.client.Session.Update().
+			Where(
+				session.TokenHash(hashToken(token)),
+				session.KindEQ(session.KindCookie),
+				session.RevokedAtIsNil(),
+			).
+			SetRevokedAt(time.Now()).
+			Save(ctx)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	setSessionCookie(c, "", -1)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// @Summary Get the current user
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ent.User
+// @Failure 401 {object} Problem
+// @Router /auth/me [get]
+func (srv *Server) GetMe(c *gin.Context) {
+	c.JSON(http.StatusOK, currentUser(c))
+}
+
+// RequireAuth rejects requests that carry neither a valid bearer token
+// (JWT access token or personal API token) nor a valid session cookie, and
+// stores the authenticated user in the request context.
+func (srv *Server) RequireAuth(c *gin.Context) {
+	ctx, err := srv.authenticate(c)
+	if err != nil {
+		// errUnauthenticated becomes a 401
+		abort(c, err)
+		return
+	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// authenticate resolves the user of a request from its bearer token, or
+// from its session cookie when there is no Authorization header, and
+// returns the request context carrying it. Requests authenticated with an
+// API token also carry the token's scopes.
+func (srv *Server) authenticate(c *gin.Context) (context.Context, error) {
+	ctx := c.Request.Context()
+	if token := bearerToken(c); strings.HasPrefix(token, apiTokenPrefix) {
+		t, err := srv.authenticateAPIToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return withScopes(withUser(ctx, t.Edges.User), t.Scopes), nil
+	} else if token != "" {
+		id, err := srv.parseAccessToken(token)
+		if err != nil {
+			return nil, errUnauthenticated
+		}
+		u, err := srv.client.User.Get(ctx, id)
+		if ent.IsNotFound(err) {
+			return nil, errUnauthenticated
+		}
+		if err != nil {
+			return nil, err
+		}
+		return withUser(ctx, u), nil
+	}
+	token, err := c.Cookie(sessionCookie)
+	if err != nil {
+		return nil, errUnauthenticated
+	}
+	s, err := srv.client.Session.Query().
+		Where(
+			session.TokenHash(hashToken(token)),
+			session.KindEQ(session.KindCookie),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	return withUser(ctx, s.Edges.User), nil
+}
+
+// userCtxKey is the request context key of the authenticated *ent.User.
+type userCtxKey struct{}
+
+// withUser returns a copy of ctx carrying u.
+func withUser(ctx context.Context, u *ent.User) context.Context {
+	// Changes made by the request are attributed to the user
+	ctx = audit.WithActor(ctx, u.ID)
+	return context.WithValue(ctx, userCtxKey{}, u)
+}
+
+// userFromContext returns the user stored by withUser, or nil.
+func userFromContext(ctx context.Context) *ent.User {
+	u, _ := ctx.Value(userCtxKey{}).(*ent.User)
+	return u
+}
+
+// currentUser returns the user authenticated by RequireAuth.
+func currentUser(c *gin.Context) *ent.User {
+	return userFromContext(c.Request.Context())
+}
+
+// checkCredentials binds the credentials of a login request and verifies
+// them, writing the error response and returning false on failure.
+func (srv *Server) checkCredentials(c *gin.Context) (*ent.User, bool) {
+	var creds Credentials
+	if !bindJSON(c, &creds) {
+		return nil, false
+	}
+	u, err := srv.client.User.Query().
+		Where(user.Email(strings.ToLower(strings.TrimSpace(creds.Email)))).
+		Only(c.Request.Context())
+	if err != nil && !ent.IsNotFound(err) {
+		c.Error(err)
+		return nil, false
+	}
+	if u == nil || bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(creds.Password)) != nil {
+		c.Error(problem(http.StatusUnauthorized, codeInvalidCredentials, "Invalid email or password"))
+		return nil, false
+	}
+	return u, true
+}
+
+// issueTokens signs a new access token for the user and stores a new
+// refresh token in the given family.
+func (srv *Server) issueTokens(ctx context.Context, userID int, family string) (*TokenResponse, error) {
+	access, err := srv.newAccessToken(userID)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	_, err = srv.client.Session.Create().
+		SetUserID(userID).
+		SetKind(session.KindRefresh).
+		SetTokenHash(hashToken(refresh)).
+		SetFamily(family).
+		SetExpiresAt(time.Now().Add(refreshTokenTTL)).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenResponse{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
+		RefreshToken: refresh,
+	}, nil
+}
+
+// revokeFamily revokes every refresh token of a login.
+func (srv *Server) revokeFamily(ctx context.Context, family string) error {
+	return srv.client.Session.Update().
+		Where(session.Family(family), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Exec(ctx)
+}
+
+// setSessionCookie writes the session cookie. The cookie is unreadable from
+// scripts and never sent on cross-site requests.
+func setSessionCookie(c *gin.Context, token string, maxAge int) {
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(sessionCookie, token, maxAge, "/", "", true, true)
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer" header.
+func bearerToken(c *gin.Context) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// newToken returns a random URL-safe token.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of a token as stored in the database.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"api/ent"
+	"api/server"
+)
+
+func TestRegisterAndLogin(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	creds := map[string]string{"email": "new@example.com", "password": "password1", "timezone": "Europe/Berlin"}
+	var u ent.User
+	ts.call(http.MethodPost, "/auth/register", creds, http.StatusOK, &u)
+	if u.Email != "new@example.com" || u.Timezone != "Europe/Berlin" {
+		t.Errorf("got user %+v", u)
+	}
+	ts.call(http.MethodPost, "/auth/register", creds, http.StatusConflict, nil)
+	ts.call(http.MethodPost, "/auth/register", map[string]string{"email": "short@example.com", "password": "short"}, http.StatusUnprocessableEntity, nil)
+
+	ts.call(http.MethodPost, "/auth/login", map[string]string{"email": "new@example.com", "password": "wrong password"}, http.StatusUnauthorized, nil)
+	ts.call(http.MethodPost, "/auth/login", map[string]string{"email": "nobody@example.com", "password": "password1"}, http.StatusUnauthorized, nil)
+	var tokens server.TokenResponse
+	ts.call(http.MethodPost, "/auth/login", creds, http.StatusOK, &tokens)
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" || tokens.TokenType != "Bearer" {
+		t.Fatalf("got tokens %+v", tokens)
+	}
+	ts.token = tokens.AccessToken
+	var me ent.User
+	ts.call(http.MethodGet, "/auth/me", nil, http.StatusOK, &me)
+	if me.ID != u.ID {
+		t.Errorf("got user %d, want %d", me.ID, u.ID)
+	}
+	ts.token = "not a token"
+	ts.call(http.MethodGet, "/auth/me", nil, http.StatusUnauthorized, nil)
+}
+
+func TestHabitsBelongToTheirOwner(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	var h ent.Habit
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "Read"}, http.StatusOK, &h)
+	path := fmt.Sprintf("/habits/%d", h.ID)
+	ts.call(http.MethodPost, path+"/checkins", nil, http.StatusOK, nil)
+
+	ts.token = ts.signUp("other@example.com")
+	var habits []ent.Habit
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, &habits)
+	if len(habits) != 0 {
+		t.Errorf("got %d habits of another user", len(habits))
+	}
+	ts.call(http.MethodGet, path, nil, http.StatusNotFound, nil)
+	ts.call(http.MethodPut, path, map[string]any{"name": "Mine"}, http.StatusNotFound, nil)
+	ts.call(http.MethodDelete, path, nil, http.StatusNotFound, nil)
+	ts.call(http.MethodGet, path+"/checkins", nil, http.StatusNotFound, nil)
+	ts.call(http.MethodPost, path+"/checkins", nil, http.StatusNotFound, nil)
+	// The other user can use the same name
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "Read"}, http.StatusOK, nil)
+}
+
+func TestRefreshRotation(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	creds := map[string]string{"email": "user@example.com", "password": "password1"}
+	var first, second, third server.TokenResponse
+	ts.call(http.MethodPost, "/auth/login", creds, http.StatusOK, &first)
+	ts.call(http.MethodPost, "/auth/refresh", server.RefreshRequest{RefreshToken: first.RefreshToken}, http.StatusOK, &second)
+	if second.RefreshToken == first.RefreshToken || second.AccessToken == "" {
+		t.Fatalf("got tokens %+v, want a new refresh token", second)
+	}
+	ts.token = second.AccessToken
+	ts.call(http.MethodGet, "/auth/me", nil, http.StatusOK, nil)
+
+	// Replaying the used token revokes the tokens issued after it
+	ts.call(http.MethodPost, "/auth/refresh", server.RefreshRequest{RefreshToken: first.RefreshToken}, http.StatusUnauthorized, nil)
+	ts.call(http.MethodPost, "/auth/refresh", server.RefreshRequest{RefreshToken: second.RefreshToken}, http.StatusUnauthorized, nil)
+
+	// Logging out revokes the login, other logins stay
+	var other server.TokenResponse
+	ts.call(http.MethodPost, "/auth/login", creds, http.StatusOK, &third)
+	ts.call(http.MethodPost, "/auth/login", creds, http.StatusOK, &other)
+	ts.call(http.MethodPost, "/auth/logout", server.RefreshRequest{RefreshToken: third.RefreshToken}, http.StatusOK, nil)
+	ts.call(http.MethodPost, "/auth/refresh", server.RefreshRequest{RefreshToken: third.RefreshToken}, http.StatusUnauthorized, nil)
+	ts.call(http.MethodPost, "/auth/refresh", server.RefreshRequest{RefreshToken: other.RefreshToken}, http.StatusOK, nil)
+}
+
+func TestCookieSession(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	ts.token = ""
+	resp := ts.call(http.MethodPost, "/auth/session", map[string]string{"email": "user@example.com", "password": "password1"}, http.StatusOK, nil)
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "session" {
+			cookie = c
+		}
+	}
+	if cookie == nil || !cookie.HttpOnly {
+		t.Fatalf("got cookies %v, want an HttpOnly session cookie", resp.Cookies())
+	}
+	header := cookie.Name + "=" + cookie.Value
+	ts.call(http.MethodGet, "/auth/me", nil, http.StatusOK, nil, "Cookie", header)
+	ts.call(http.MethodDelete, "/auth/session", nil, http.StatusOK, nil, "Cookie", header)
+	ts.call(http.MethodGet, "/auth/me", nil, http.StatusUnauthorized, nil, "Cookie", header)
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/habit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Batch modes.
+const (
+	batchAllOrNothing = "all_or_nothing"
+	batchPerItem      = "per_item"
+)
+
+// Batch operations.
+const (
+	batchCreate    = "create"
+	batchUpdate    = "update"
+	batchDelete    = "delete"
+	batchArchive   = "archive"
+	batchUnarchive = "unarchive"
+)
+
+// BatchOperation is one change in a batch.
+type BatchOperation struct {
+	Op string `json:"op" binding:"required,oneof=create update delete archive unarchive" example:"create"`
+	// ID is the habit to change, for every operation but create.
+	ID int `json:"id,omitempty"`
+	// Version makes the operation fail with 412 if the habit has another
+	// version, like If-Match.
+	Version int `json:"version,omitempty"`
+	// Habit is the habit to create, or the new fields of the habit to
+	// update. It is validated like the body of POST /habits.
+	Habit *HabitInput `json:"habit,omitempty" binding:"-"`
+}
+
+// BatchRequest is the body of POST /habits:batch.
+type BatchRequest struct {
+	// Mode all_or_nothing, the default, applies every operation or none;
+	// per_item applies each operation on its own.
+	Mode       string           `json:"mode,omitempty" binding:"omitempty,oneof=all_or_nothing per_item" example:"all_or_nothing"`
+	Operations []BatchOperation `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BatchResult is the outcome of the operation at the same index.
+type BatchResult struct {
+	Status int `json:"status" example:"200"`
+	// Habit is the created or changed habit, unset for delete.
+	Habit *ent.Habit `json:"habit,omitempty"`
+	// EventID is the audit event of a delete, to pass to POST /undo.
+	EventID int `json:"event_id,omitempty"`
+	// Error tells why the operation failed.
+	Error *Problem `json:"error,omitempty"`
+}
+
+// BatchResponse holds the results of the operations of a batch.
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// habitsMethod routes the custom methods of the habit collection. Gin has
+// no literal colons in paths, so they are a wildcard after "/habits".
+func (srv *Server) habitsMethod(c *gin.Context) {
+	switch c.Param("method") {
+	case ":batch":
+		srv.BatchHabits(c)
+	default:
+		c.Error(notFound("No such endpoint"))
+	}
+}
+
+// @Summary Apply several habit operations at once
+// @Description Creates, updates, deletes, archives and unarchives up to 100 habits in one request. In all_or_nothing mode the operations run in a single transaction: if one fails, none is applied and the response is the problem of the failed operation, whose index is in the detail and the field names. In per_item mode every operation runs in its own transaction and gets its own status in the results; the response is a 207 if any of them failed, and is replayed like any other for its Idempotency-Key.
+// @Accept json
+// @Produce json
+// @Param batch body BatchRequest true "Operations to apply in order"
+// @Param Idempotency-Key header string false "Unique key making retries return the first response instead of applying the operations again"
+// @Success 200 {object} BatchResponse
+// @Success 207 {object} BatchResponse "Some operations of a per_item batch failed"
+// @Header 200 {string} Idempotent-Replayed "true if the response is the stored one of an earlier request with the same key"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 422 {object} Problem
+// @Security BearerAuth
+// @Router /habits:batch [post]
+func (srv *Server) BatchHabits(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req BatchRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if err := validateBatch(req.Operations); err != nil {
+		c.Error(err)
+		return
+	}
+	ownerID := currentUser(c).ID
+	results := make([]BatchResult, len(req.Operations))
+
+	if req.Mode == batchPerItem {
+		// Earlier operations stay applied when a later one fails, even on
+		// a server error, so the response always reports every result
+		status := http.StatusOK
+		for i, op := range req.Operations {
+			var h *ent.Habit
+			var events []int
+			err := withTx(ctx, srv.client, func(tx *ent.Tx) (err error) {
+				h, err = applyBatchOperation(audit.WithEventIDs(ctx, &events), tx.Client(), ownerID, op)
+				return err
+			})
+			if err != nil {
+				e := toAPIError(srv.batchError(ctx, ownerID, op, err))
+				if e.status >= http.StatusInternalServerError {
+					slog.Error("batch operation failed", "index", i, "op", op.Op, "err", err)
+				}
+				p := e.toProblem(c.Request.URL.Path)
+				results[i] = BatchResult{Status: e.status, Error: &p}
+				status = http.StatusMultiStatus
+				continue
+			}
+			results[i] = batchResult(op, h, events)
+		}
+		c.JSON(status, BatchResponse{Results: results})
+		return
+	}
+
+	habits := make([]*ent.Habit, len(req.Operations))
+	events := make([][]int, len(req.Operations))
+	failed := -1
+	err := withTx(ctx, srv.client, func(tx *ent.Tx) error {
+		for i, op := range req.Operations {
+			h, err := applyBatchOperation(audit.WithEventIDs(ctx, &events[i]), tx.Client(), ownerID, op)
+			if err != nil {
+				failed = i
+				return err
+			}
+			habits[i] = h
+		}
+		return nil
+	})
+	if failed >= 0 {
+		e := toAPIError(srv.batchError(ctx, ownerID, req.Operations[failed], err))
+		if e.status >= http.StatusInternalServerError {
+			c.Error(err)
+			return
+		}
+		c.Error(atOperation(failed, e))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i, op := range req.Operations {
+		results[i] = batchResult(op, habits[i], events[i])
+	}
+	c.JSON(http.StatusOK, BatchResponse{Results: results})
+}
+
+// validateBatch checks what the binding tags of the operations cannot and
+// normalizes their habits, reporting every invalid field at once.
+func validateBatch(ops []BatchOperation) error {
+	var fields []FieldError
+	for i := range ops {
+		op := &ops[i]
+		prefix := fmt.Sprintf("operations[%d]", i)
+		if op.Op != batchCreate && op.ID <= 0 {
+			fields = append(fields, FieldError{Field: prefix + ".id", Message: "is required for " + op.Op})
+		}
+		if op.Op != batchCreate && op.Op != batchUpdate {
+			continue
+		}
+		if op.Habit == nil {
+			fields = append(fields, FieldError{Field: prefix + ".habit", Message: "is required for " + op.Op})
+			continue
+		}
+		err := validateHabit(op.Habit)
+		var e *apiError
+		if errors.As(err, &e) {
+			fields = append(fields, atOperation(i, e).fields...)
+		} else if err != nil {
+			return err
+		}
+	}
+	if len(fields) > 0 {
+		return validationFailed(fields)
+	}
+	return nil
+}
+
+// applyBatchOperation applies a validated operation through client, which
+// is bound to the transaction of the operation.
+func applyBatchOperation(ctx context.Context, client *ent.Client, ownerID int, op BatchOperation) (*ent.Habit, error) {
+	switch op.Op {
+	case batchCreate:
+		return createHabit(client, ownerID, op.Habit).Save(ctx)
+	case batchDelete:
+		del := client.Habit.DeleteOneID(op.ID).Where(habit.OwnerID(ownerID))
+		if op.Version != 0 {
+			del.Where(habit.Version(op.Version))
+		}
+		return nil, del.Exec(ctx)
+	}
+	update := client.Habit.UpdateOneID(op.ID).Where(habit.OwnerID(ownerID))
+	if op.Version != 0 {
+		update.Where(habit.Version(op.Version))
+	}
+	switch op.Op {
+	case batchUpdate:
+		replaceHabit(update, op.Habit)
+	case batchArchive:
+		update.SetArchived(true)
+	case batchUnarchive:
+		update.SetArchived(false)
+	}
+	return update.Save(ctx)
+}
+
+// batchError explains why an operation failed, like the endpoints for
+// single habits do. It queries outside the transaction, which Postgres
+// refuses to use after an error.
+func (srv *Server) batchError(ctx context.Context, ownerID int, op BatchOperation, err error) error {
+	switch {
+	case ent.IsNotFound(err) && op.Op != batchCreate:
+		return srv.habitWriteError(ctx, ownerID, op.ID)
+	case ent.IsConstraintError(err) && op.Habit != nil:
+		return srv.habitNameError(ctx, ownerID, op.Habit.Name)
+	}
+	return err
+}
+
+// batchResult reports a successful operation, given the events it
+// recorded.
+func batchResult(op BatchOperation, h *ent.Habit, events []int) BatchResult {
+	if op.Op == batchDelete {
+		return BatchResult{Status: http.StatusOK, EventID: lastEventID(events)}
+	}
+	return BatchResult{Status: http.StatusOK, Habit: h}
+}
+
+// atOperation locates an error in the operation at index i of a batch.
+func atOperation(i int, e *apiError) *apiError {
+	prefix := fmt.Sprintf("operations[%d]", i)
+	located := *e
+	located.detail = prefix + ": " + e.detail
+	located.fields = make([]FieldError, len(e.fields))
+	for j, f := range e.fields {
+		located.fields[j] = FieldError{Field: prefix + ".habit." + f.Field, Message: f.Message}
+	}
+	return &located
+}
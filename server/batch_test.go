@@ -0,0 +1,92 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"api/ent"
+	"api/ent/hook"
+	"api/server"
+)
+
+func TestBatchAtomic(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+
+	// The duplicate name fails the second operation and rolls back the first
+	ts.call(http.MethodPost, "/habits:batch", map[string]any{"operations": []map[string]any{
+		{"op": "create", "habit": map[string]any{"name": "Write"}},
+		{"op": "create", "habit": map[string]any{"name": "read"}},
+	}}, http.StatusConflict, nil)
+	var habits []map[string]any
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, &habits)
+	if len(habits) != 1 {
+		t.Fatalf("got %d habits after a failed batch, want 1", len(habits))
+	}
+
+	var resp server.BatchResponse
+	ts.call(http.MethodPost, "/habits:batch", map[string]any{"operations": []map[string]any{
+		{"op": "create", "habit": map[string]any{"name": "Write"}},
+		{"op": "delete", "id": h.ID},
+	}}, http.StatusOK, &resp)
+	if len(resp.Results) != 2 || resp.Results[0].Habit == nil || resp.Results[1].EventID == 0 {
+		t.Fatalf("got results %+v, want the created habit and the event of the deletion", resp.Results)
+	}
+	ts.call(http.MethodPost, fmt.Sprintf("/undo/%d", resp.Results[1].EventID), nil, http.StatusOK, nil)
+}
+
+func TestBatchPerItem(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	ts.createHabit(map[string]any{"name": "Read"})
+
+	var resp server.BatchResponse
+	ts.call(http.MethodPost, "/habits:batch", map[string]any{"mode": "per_item", "operations": []map[string]any{
+		{"op": "create", "habit": map[string]any{"name": "Write"}},
+		{"op": "create", "habit": map[string]any{"name": "read"}},
+		{"op": "delete", "id": 999},
+	}}, http.StatusMultiStatus, &resp)
+	want := []int{http.StatusOK, http.StatusConflict, http.StatusNotFound}
+	for i, r := range resp.Results {
+		if r.Status != want[i] {
+			t.Errorf("got status %d for operation %d, want %d", r.Status, i, want[i])
+		}
+	}
+}
+
+func TestBatchPerItemServerError(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	ts.client.Habit.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.HabitFunc(func(ctx context.Context, m *ent.HabitMutation) (ent.Value, error) {
+			if name, _ := m.Name(); name == "Fail" {
+				return nil, errors.New("storage failed")
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	// The first operation stays applied, and a retry must not repeat it
+	body := map[string]any{"mode": "per_item", "operations": []map[string]any{
+		{"op": "create", "habit": map[string]any{"name": "Read"}},
+		{"op": "create", "habit": map[string]any{"name": "Fail"}},
+	}}
+	var resp server.BatchResponse
+	ts.call(http.MethodPost, "/habits:batch", body, http.StatusMultiStatus, &resp, "Idempotency-Key", "onboarding")
+	if len(resp.Results) != 2 || resp.Results[0].Status != http.StatusOK || resp.Results[1].Status != http.StatusInternalServerError {
+		t.Fatalf("got results %+v, want 200 and 500", resp.Results)
+	}
+	replayed := ts.call(http.MethodPost, "/habits:batch", body, http.StatusMultiStatus, nil, "Idempotency-Key", "onboarding")
+	if replayed.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("got the batch applied again, want its response replayed")
+	}
+	var habits []map[string]any
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, &habits)
+	if len(habits) != 1 {
+		t.Errorf("got %d habits, want 1", len(habits))
+	}
+}
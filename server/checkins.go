@@ -0,0 +1,204 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/completion"
+	"api/ent/habit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CheckinInput is the request body for recording a check-in.
+type CheckinInput struct {
+	// CompletedAt defaults to the time of the request when omitted.
+	CompletedAt *time.Time `json:"completed_at"`
+	Note        string     `json:"note"`
+	// Value is the amount done, required for numeric habits (in their unit)
+	// and duration habits (in minutes) and not accepted for boolean ones.
+	// The values of a day add up towards the target of the habit.
+	Value *float64 `json:"value,omitempty" binding:"omitempty,min=0" example:"2.5"`
+}
+
+// @Summary Check in a habit
+// @Description Check-ins of numeric and duration habits carry a value. A day counts as done once the values checked in that day meet the target of the habit.
+// @Accept json
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param checkin body CheckinInput false "Check-in to record"
+// @Param Idempotency-Key header string false "Unique key making retries return the first response instead of checking in again"
+// @Success 200 {object} ent.Completion
+// @Header 200 {string} Idempotent-Replayed "true if the response is the stored one of an earlier request with the same key"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/checkins [post]
+func (srv *Server) CreateCheckin(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	var input CheckinInput
+	if c.Request.ContentLength != 0 {
+		if !bindJSON(c, &input) {
+			return
+		}
+	}
+	h, err := srv.ownedHabits(c).Where(habit.ID(id)).Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(notFound("Habit not found"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	switch {
+	case h.Type == habit.TypeBoolean && input.Value != nil:
+		c.Error(invalidField("value", "is only accepted for numeric and duration habits"))
+		return
+	case h.Type != habit.TypeBoolean && input.Value == nil:
+		c.Error(invalidField("value", fmt.Sprintf("is required for %s habits", h.Type)))
+		return
+	}
+	create := srv.client.Completion.Create().
+		SetHabitID(id).
+		SetNote(stripMarkers(input.Note)).
+		SetNillableValue(input.Value)
+	if input.CompletedAt != nil {
+		create.SetCompletedAt(*input.CompletedAt)
+	}
+	cp, err := create.Save(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, cp)
+}
+
+// @Summary List check-ins of a habit
+// @Description Dates accept either YYYY-MM-DD or RFC 3339; both bounds are inclusive.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param from query string false "Earliest check-in date"
+// @Param to query string false "Latest check-in date"
+// @Success 200 {array} ent.Completion
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/checkins [get]
+func (srv *Server) GetCheckins(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	query := srv.client.Completion.Query().Where(completion.HabitID(id))
+	if from := c.Query("from"); from != "" {
+		t, err := parseDateParam(from, false)
+		if err != nil {
+			c.Error(invalidParam("Invalid from date"))
+			return
+		}
+		query.Where(completion.CompletedAtGTE(t))
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := parseDateParam(to, true)
+		if err != nil {
+			c.Error(invalidParam("Invalid to date"))
+			return
+		}
+		query.Where(completion.CompletedAtLT(t))
+	}
+	exists, err := srv.ownedHabits(c).Where(habit.ID(id)).Exist(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !exists {
+		c.Error(notFound("Habit not found"))
+		return
+	}
+	checkins, err := query.Order(ent.Desc(completion.FieldCompletedAt)).All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, checkins)
+}
+
+// @Summary Delete a check-in
+// @Description The response holds the event_id to pass to POST /undo.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param checkinId path int true "Check-in ID"
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/checkins/{checkinId} [delete]
+func (srv *Server) DeleteCheckin(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	checkinID, err := toInt(c.Param("checkinId"))
+	if err != nil {
+		c.Error(invalidParam("Invalid check-in ID"))
+		return
+	}
+	var events []int
+	n, err := srv.client.Completion.Delete().
+		Where(
+			completion.ID(checkinID),
+			completion.HabitID(id),
+			completion.HasHabitWith(habit.OwnerID(currentUser(c).ID), habit.DeletedAtIsNil()),
+		).
+		Exec(audit.WithEventIDs(ctx, &events))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if n == 0 {
+		c.Error(notFound("Check-in not found"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Check-in deleted successfully", "event_id": lastEventID(events)})
+}
+
+// parseDateParam accepts a plain date or an RFC 3339 timestamp. A plain date
+// used as an upper bound is moved to the start of the following day so that
+// the whole day is included.
+func parseDateParam(s string, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		if upper {
+			return t.Add(time.Nanosecond), nil
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		return t.AddDate(0, 0, 1), nil
+	}
+	return t, nil
+}
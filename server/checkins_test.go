@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"api/ent"
+)
+
+// checkIn creates a check-in of a habit and returns it.
+func (ts *testServer) checkIn(habitID int, body map[string]any) *ent.Completion {
+	ts.t.Helper()
+	var cp ent.Completion
+	ts.call(http.MethodPost, fmt.Sprintf("/habits/%d/checkins", habitID), body, http.StatusOK, &cp)
+	return &cp
+}
+
+func TestCheckins(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	path := fmt.Sprintf("/habits/%d/checkins", h.ID)
+	ts.checkIn(h.ID, map[string]any{"completed_at": "2026-01-10T08:00:00Z", "note": "chapter 1"})
+	ts.checkIn(h.ID, map[string]any{"completed_at": "2026-01-11T08:00:00Z"})
+	last := ts.checkIn(h.ID, map[string]any{"completed_at": "2026-01-12T08:00:00Z"})
+
+	var all []ent.Completion
+	ts.call(http.MethodGet, path, nil, http.StatusOK, &all)
+	if len(all) != 3 || all[0].ID != last.ID {
+		t.Fatalf("got %d check-ins starting with %d, want 3 starting with %d", len(all), all[0].ID, last.ID)
+	}
+	// Both bounds are inclusive
+	var some []ent.Completion
+	ts.call(http.MethodGet, path+"?from=2026-01-10&to=2026-01-11", nil, http.StatusOK, &some)
+	if len(some) != 2 {
+		t.Errorf("got %d check-ins from 2026-01-10 to 2026-01-11, want 2", len(some))
+	}
+	ts.call(http.MethodGet, path+"?from=yesterday", nil, http.StatusBadRequest, nil)
+
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("%s/%d", path, last.ID), nil, http.StatusOK, &deleted)
+	if deleted.EventID == 0 {
+		t.Error("got no event_id for the deletion")
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("%s/%d", path, last.ID), nil, http.StatusNotFound, nil)
+	ts.call(http.MethodGet, "/habits/999/checkins", nil, http.StatusNotFound, nil)
+}
+
+func TestCheckinsInOtherOffsets(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	path := fmt.Sprintf("/habits/%d/checkins", h.ID)
+	// 22:30 UTC, before the second check-in although its local day is later
+	early := ts.checkIn(h.ID, map[string]any{"completed_at": "2026-01-11T00:30:00+02:00"})
+	late := ts.checkIn(h.ID, map[string]any{"completed_at": "2026-01-10T23:00:00Z"})
+
+	var all []ent.Completion
+	ts.call(http.MethodGet, path, nil, http.StatusOK, &all)
+	if len(all) != 2 || all[0].ID != late.ID || all[1].ID != early.ID {
+		t.Errorf("got check-ins %+v, want %d then %d", all, late.ID, early.ID)
+	}
+	var some []ent.Completion
+	ts.call(http.MethodGet, path+"?from=2026-01-11T00:45:00%2B02:00", nil, http.StatusOK, &some)
+	if len(some) != 1 || some[0].ID != late.ID {
+		t.Errorf("got check-ins %+v from 22:45 UTC, want %d", some, late.ID)
+	}
+	ts.call(http.MethodGet, path+"?to=2026-01-10T22:45:00Z", nil, http.StatusOK, &some)
+	if len(some) != 1 || some[0].ID != early.ID {
+		t.Errorf("got check-ins %+v until 22:45 UTC, want %d", some, early.ID)
+	}
+}
+
+func TestCheckinValues(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	boolean := ts.createHabit(map[string]any{"name": "Read"})
+	numeric := ts.createHabit(map[string]any{"name": "Water", "type": "numeric", "unit": "glasses", "target": 8})
+
+	ts.call(http.MethodPost, fmt.Sprintf("/habits/%d/checkins", boolean.ID), map[string]any{"value": 1}, http.StatusUnprocessableEntity, nil)
+	ts.call(http.MethodPost, fmt.Sprintf("/habits/%d/checkins", numeric.ID), map[string]any{}, http.StatusUnprocessableEntity, nil)
+	ts.call(http.MethodPost, fmt.Sprintf("/habits/%d/checkins", numeric.ID), map[string]any{"value": -1}, http.StatusUnprocessableEntity, nil)
+	cp := ts.checkIn(numeric.ID, map[string]any{"value": 3})
+	if cp.Value == nil || *cp.Value != 3 {
+		t.Errorf("got value %v, want 3", cp.Value)
+	}
+}
+
+func TestCheckinsOfTrashedHabit(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	cp := ts.checkIn(h.ID, map[string]any{"completed_at": time.Now().UTC().Format(time.RFC3339)})
+	ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d", h.ID), nil, http.StatusOK, nil)
+	ts.call(http.MethodPost, fmt.Sprintf("/habits/%d/checkins", h.ID), nil, http.StatusNotFound, nil)
+	ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d/checkins/%d", h.ID, cp.ID), nil, http.StatusNotFound, nil)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api/ent"
+	"api/ent/habit"
+	"api/ent/predicate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// habitETag is the strong entity tag of a habit as returned by the write
+// endpoints. It is the version, which every update increments.
+func habitETag(h *ent.Habit) string {
+	return `"` + strconv.Itoa(h.Version) + `"`
+}
+
+// habitResponseETag also covers the history, which changes with check-ins
+// and days while the version stays the same. The version comes first so If-Match
+// still recognizes the tag.
+func habitResponseETag(r HabitResponse) string {
+	b, _ := json.Marshal(r.History)
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf(`"%d-%x"`, r.Version, sum[:4])
+}
+
+// ifMatch turns the If-Match header into a predicate on the habit version.
+// It returns nil when the header is absent or "*", so the write is
+// unconditional. Weak tags never match.
+func ifMatch(c *gin.Context) predicate.Habit {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		v, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+		if n, err := strconv.Atoi(v); err == nil {
+			versions = append(versions, n)
+		}
+	}
+	return habit.VersionIn(versions...)
+}
+
+// notModified reports whether the If-None-Match header lists etag, i.e.
+// whether the client's copy is current. It uses the weak comparison.
+func notModified(c *gin.Context, etag string) bool {
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// errHabitModified answers writes whose If-Match did not match.
+var errHabitModified = problem(http.StatusPreconditionFailed, codePreconditionFailed, "Habit was modified, reload it and try again")
+
+// habitWriteFailed answers a conditional write that matched no row: 412 if
+// the habit exists but has another version, 404 otherwise.
+func (srv *Server) habitWriteFailed(ctx context.Context, c *gin.Context, id int) {
+	c.Error(srv.habitWriteError(ctx, currentUser(c).ID, id))
+}
+
+// habitWriteError is the error habitWriteFailed answers with.
+func (srv *Server) habitWriteError(ctx context.Context, ownerID, id int) error {
+	exists, err := srv.client.Habit.Query().
+		Where(habit.ID(id), habit.OwnerID(ownerID)).
+		Exist(ctx)
+	switch {
+	case err != nil:
+		return err
+	case exists:
+		return errHabitModified
+	default:
+		return notFound("Habit not found")
+	}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"api/ent"
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// colorPattern matches the colors accepted for habits, after lowercasing.
+var colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// HabitInput holds the fields of a habit a client may set. It is the body
+// of the create and update endpoints and the document patches are applied
+// to, so requests cannot change the ID, owner, version or timestamps.
+// Unknown fields in a request body are ignored.
+type HabitInput struct {
+	Name        string `json:"name" binding:"required,max=100" example:"Read"`
+	Description string `json:"description,omitempty" binding:"max=1000"`
+	// Color is a hex color like "#1e90ff".
+	Color    string         `json:"color,omitempty" binding:"omitempty,color" example:"#1e90ff"`
+	Schedule habit.Schedule `json:"schedule"`
+	// Weekdays (0 = Sunday) for the "weekdays" schedule.
+	Weekdays []int `json:"weekdays,omitempty"`
+	// TimesPerPeriod for the "weekly" and "monthly" schedules.
+	TimesPerPeriod int `json:"times_per_period,omitempty"`
+	// IntervalDays for the "interval" schedule.
+	IntervalDays int `json:"interval_days,omitempty"`
+	// Rrule is an RFC 5545 rule for the "rrule" schedule.
+	Rrule string `json:"rrule,omitempty"`
+	// Polarity is build, or quit for a habit whose check-ins are slips. A
+	// habit to quit must be daily and boolean.
+	Polarity habit.Polarity `json:"polarity" example:"build"`
+	// Type is boolean, numeric or duration. Check-ins of measured habits
+	// carry a value, in Unit for numeric and in minutes for duration habits.
+	Type habit.Type `json:"type" example:"boolean"`
+	Unit string     `json:"unit,omitempty" binding:"max=20" example:"km"`
+	// Target is what the values checked in on a day must add up to, at
+	// least or at most depending on TargetDirection. Required for measured
+	// habits.
+	Target          *float64              `json:"target,omitempty" example:"5"`
+	TargetDirection habit.TargetDirection `json:"target_direction,omitempty" example:"at_least"`
+	Tags            []string              `json:"tags,omitempty" binding:"max=20,dive,max=50"`
+	Archived        bool                  `json:"archived"`
+}
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("color", func(fl validator.FieldLevel) bool {
+			return colorPattern.MatchString(fl.Field().String())
+		})
+	}
+}
+
+// normalize trims the text fields, strips the search markers from the name
+// and description, lowercases the color and drops empty and duplicate tags.
+func (in *HabitInput) normalize() {
+	in.Name = strings.TrimSpace(stripMarkers(in.Name))
+	in.Description = strings.TrimSpace(stripMarkers(in.Description))
+	in.Color = strings.ToLower(strings.TrimSpace(in.Color))
+	in.Rrule = strings.TrimSpace(in.Rrule)
+	in.Unit = strings.TrimSpace(in.Unit)
+	in.Tags = normalizeTags(in.Tags)
+}
+
+// bindHabit decodes a habit from the request body and validates it like
+// validateHabit. On failure it records the error for the Problems
+// middleware and returns false.
+func bindHabit(c *gin.Context, in *HabitInput) bool {
+	if err := json.NewDecoder(c.Request.Body).Decode(in); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return false
+	}
+	if err := validateHabit(in); err != nil {
+		c.Error(err)
+		return false
+	}
+	return true
+}
+
+// validateHabit normalizes in and checks it, reporting every invalid field
+// at once. Whether the name is free is left to the database.
+func validateHabit(in *HabitInput) error {
+	in.normalize()
+	var fields []FieldError
+	if err := binding.Validator.ValidateStruct(in); err != nil {
+		var verrs validator.ValidationErrors
+		if !errors.As(err, &verrs) {
+			return err
+		}
+		fields = fieldErrors(verrs)
+	}
+	fields = append(fields, normalizeSchedule(in)...)
+	fields = append(fields, normalizeTarget(in)...)
+	fields = append(fields, normalizePolarity(in)...)
+	if len(fields) > 0 {
+		return validationFailed(fields)
+	}
+	return nil
+}
+
+// habitNameTaken answers a habit write that violated a unique constraint.
+// That is a name used by another habit of the user: 409 with the ID of that
+// habit.
+func (srv *Server) habitNameTaken(ctx context.Context, c *gin.Context, name string) {
+	c.Error(srv.habitNameError(ctx, currentUser(c).ID, name))
+}
+
+// habitNameError is the error habitNameTaken answers with.
+func (srv *Server) habitNameError(ctx context.Context, ownerID int, name string) error {
+	id, err := srv.client.Habit.Query().
+		Where(habit.OwnerID(ownerID), habit.NameKey(schema.HabitNameKey(name))).
+		OnlyID(ctx)
+	if ent.IsNotFound(err) {
+		// Another constraint failed
+		return problem(http.StatusConflict, codeConflict, "Conflicts with existing data")
+	}
+	if err != nil {
+		return err
+	}
+	e := problem(http.StatusConflict, codeConflict, fmt.Sprintf("A habit named %q already exists", name))
+	e.existingID = id
+	return e
+}
+
+// createHabit builds the creation of a habit from validated input.
+func createHabit(client *ent.Client, ownerID int, in *HabitInput) *ent.HabitCreate {
+	create := client.Habit.Create().
+		SetOwnerID(ownerID).
+		SetName(in.Name).
+		SetDescription(in.Description).
+		SetArchived(in.Archived).
+		SetPolarity(in.Polarity)
+	if in.Color != "" {
+		create.SetColor(in.Color)
+	}
+	if in.Tags != nil {
+		create.SetTags(in.Tags)
+	}
+	setSchedule(create.Mutation(), in)
+	setTarget(create.Mutation(), in)
+	return create
+}
+
+// replaceHabit sets every editable field of a habit update from validated
+// input.
+func replaceHabit(update *ent.HabitUpdateOne, in *HabitInput) *ent.HabitUpdateOne {
+	update.
+		SetName(in.Name).
+		SetDescription(in.Description).
+		SetArchived(in.Archived).
+		SetPolarity(in.Polarity)
+	if in.Color != "" {
+		update.SetColor(in.Color)
+	} else {
+		update.ClearColor()
+	}
+	if in.Tags != nil {
+		update.SetTags(in.Tags)
+	} else {
+		update.ClearTags()
+	}
+	setSchedule(update.Mutation(), in)
+	setTarget(update.Mutation(), in)
+	return update
+}
@@ -0,0 +1,553 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/completion"
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get all habits
+// @Description Habits are returned a page at a time. When there are more, the Link header points to the next page.
+// @Produce json
+// @Param limit query int false "Page size, 1 to 100" default(50)
+// @Param cursor query string false "Opaque cursor taken from the Link header of the previous page"
+// @Param sort query string false "Sort key, streak sorts habits to quit by days since the last slip" Enums(created_at, name, streak) default(created_at)
+// @Param order query string false "Sort direction (default: desc for created_at and streak, asc for name)" Enums(asc, desc)
+// @Param name query string false "Only habits whose name contains this text, ignoring case"
+// @Param created_after query string false "Only habits created after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param created_before query string false "Only habits created before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param archived query bool false "List archived instead of active habits" default(false)
+// @Param tag query []string false "Only habits with all of these tags" collectionFormat(multi)
+// @Param tz query string false "IANA time zone used to compute streaks (default: user time zone)"
+// @Success 200 {array} HabitResponse
+// @Header 200 {string} Link "<...>; rel=\"next\" when there are more habits"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Security BearerAuth
+// @Router /habits [get]
+func (srv *Server) GetHabits(c *gin.Context) {
+	ctx := c.Request.Context()
+	loc, err := requestLocation(c)
+	if err != nil {
+		c.Error(invalidParam("Invalid time zone"))
+		return
+	}
+	q, err := parseHabitListQuery(c)
+	if err != nil {
+		c.Error(invalidParam(err.Error()))
+		return
+	}
+	var after any
+	if q.cursor != nil {
+		if after, err = q.cursorValue(); err != nil {
+			c.Error(invalidParam("Invalid cursor"))
+			return
+		}
+	}
+	order := ent.Asc
+	if q.desc {
+		order = ent.Desc
+	}
+
+	query := srv.ownedHabits(c).Where(q.where...)
+	var habits []*ent.Habit
+	var histories map[int]History
+	if q.sort == "streak" {
+		// Streaks are not stored, so every matching habit is loaded and
+		// paginated in memory.
+		habits, err = query.All(ctx)
+		if err == nil {
+			histories, err = srv.loadHistories(ctx, habits, loc, time.Now())
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		habits = pageByStreak(habits, histories, q, after)
+	} else {
+		if q.cursor != nil {
+			query.Where(q.after(q.sort, after))
+		}
+		habits, err = query.
+			Order(order(q.sort), order(habit.FieldID)).
+			Limit(q.limit + 1).
+			All(ctx)
+		if err == nil {
+			histories, err = srv.loadHistories(ctx, habits, loc, time.Now())
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	if len(habits) > q.limit {
+		habits = habits[:q.limit]
+		last := habits[len(habits)-1]
+		next := habitCursor{Sort: q.sort, Desc: q.desc, ID: last.ID}
+		switch q.sort {
+		case "created_at":
+			next.Value = last.CreatedAt.Format(time.RFC3339Nano)
+		case "name":
+			next.Value = last.Name
+		case "streak":
+			next.Value = strconv.Itoa(histories[last.ID].current())
+		}
+		setNextLink(c, next.encode())
+	}
+	resp := make([]HabitResponse, len(habits))
+	for i, h := range habits {
+		resp[i] = HabitResponse{Habit: h, History: histories[h.ID]}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// pageByStreak sorts habits by current streak, then ID, and returns up to
+// one more than a page of those following the cursor.
+func pageByStreak(habits []*ent.Habit, histories map[int]History, q habitListQuery, after any) []*ent.Habit {
+	compare := func(streakA, idA, streakB, idB int) int {
+		c := cmp.Or(cmp.Compare(streakA, streakB), cmp.Compare(idA, idB))
+		if q.desc {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(habits, func(a, b *ent.Habit) int {
+		return compare(histories[a.ID].current(), a.ID, histories[b.ID].current(), b.ID)
+	})
+	if q.cursor != nil {
+		streak := after.(int)
+		i := 0
+		for i < len(habits) && compare(histories[habits[i].ID].current(), habits[i].ID, streak, q.cursor.ID) <= 0 {
+			i++
+		}
+		habits = habits[i:]
+	}
+	if len(habits) > q.limit+1 {
+		habits = habits[:q.limit+1]
+	}
+	return habits
+}
+
+// DueHabit is a habit that is scheduled on the requested day.
+type DueHabit struct {
+	*ent.Habit
+	// Done reports whether the check-ins of that day meet the target so
+	// far, or those of the period up to that day for weekly and monthly
+	// habits.
+	Done bool `json:"done"`
+	// Progress is the sum of the values checked in on that day, or in the
+	// period up to that day, for numeric and duration habits.
+	Progress *float64 `json:"progress,omitempty"`
+}
+
+// @Summary Get the habits due on a day
+// @Description Weekly and monthly habits are due until they have been checked in often enough in the current period. Numeric and duration habits report the values checked in on the day, or in the period so far, as progress, and are done once it meets their target. Habits to quit are never due.
+// @Produce json
+// @Param date query string false "Day to check as YYYY-MM-DD (default today)"
+// @Param tz query string false "IANA time zone of the day (default: user time zone)"
+// @Success 200 {array} DueHabit
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Security BearerAuth
+// @Router /habits/due [get]
+func (srv *Server) GetDueHabits(c *gin.Context) {
+	ctx := c.Request.Context()
+	loc, err := requestLocation(c)
+	if err != nil {
+		c.Error(invalidParam("Invalid time zone"))
+		return
+	}
+	day := civilDay(time.Now(), loc)
+	if date := c.Query("date"); date != "" {
+		day, err = time.Parse(time.DateOnly, date)
+		if err != nil {
+			c.Error(invalidParam("Invalid date"))
+			return
+		}
+	}
+	owner := currentUser(c).ID
+	habits, err := srv.client.Habit.Query().
+		Where(habit.OwnerID(owner), habit.Archived(false), habit.PolarityEQ(habit.PolarityBuild)).
+		Order(ent.Asc(habit.FieldName)).
+		All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	// Quota periods are at most a month, so check-ins since the start of the
+	// earlier of week and month are enough to evaluate every schedule.
+	from := day.AddDate(0, 0, 1-day.Day())
+	if weekAgo := day.AddDate(0, 0, -6); weekAgo.Before(from) {
+		from = weekAgo
+	}
+	checkins, err := srv.client.Completion.Query().
+		Where(
+			completion.HasHabitWith(habit.OwnerID(owner)),
+			completion.CompletedAtGTE(time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)),
+			completion.CompletedAtLT(time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)),
+		).
+		Select(completion.FieldHabitID, completion.FieldCompletedAt, completion.FieldValue).
+		All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	byHabit := make(map[int][]*ent.Completion)
+	for _, cp := range checkins {
+		byHabit[cp.HabitID] = append(byHabit[cp.HabitID], cp)
+	}
+	due := make([]DueHabit, 0, len(habits))
+	for _, h := range habits {
+		sched, err := newSchedule(h, loc)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		tgt := newTarget(h)
+		totals := tgt.totals(byHabit[h.ID], loc)
+		if !sched.isDue(day, tgt, totals) {
+			continue
+		}
+		d := DueHabit{Habit: h, Done: tgt.metOn(totals, day)}
+		progress := totals[day]
+		if sched.isQuota() {
+			next := day.AddDate(0, 0, 1)
+			d.Done = tgt.periodMet(totals, sched.quota, sched.periodStart(day), next)
+			_, progress = periodTotal(totals, sched.periodStart(day), next)
+		}
+		if tgt.measured {
+			d.Progress = &progress
+		}
+		due = append(due, d)
+	}
+	c.JSON(http.StatusOK, due)
+}
+
+// @Summary Get a single habit
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param tz query string false "IANA time zone used to compute streaks (default: user time zone)"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {object} HabitResponse
+// @Header 200 {string} ETag "Version of the habit and its streak"
+// @Success 304 "The cached copy is current"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id} [get]
+func (srv *Server) GetHabit(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		c.Error(invalidParam("Invalid time zone"))
+		return
+	}
+	h, err := srv.ownedHabits(c).Where(habit.ID(id)).Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(notFound("Habit not found"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	histories, err := srv.loadHistories(ctx, []*ent.Habit{h}, loc, time.Now())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resp := HabitResponse{Habit: h, History: histories[h.ID]}
+	etag := habitResponseETag(resp)
+	c.Header("ETag", etag)
+	if notModified(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Create a new habit
+// @Description Text fields are trimmed and all invalid fields are reported at once. The name must be unique among the user's habits, ignoring case: a taken name is a 409 with the existing_id of the habit, or with upsert=true that habit is returned unchanged.
+// @Accept json
+// @Produce json
+// @Param habit body HabitInput true "Habit to create"
+// @Param upsert query bool false "Return the existing habit of the same name instead of failing"
+// @Param Idempotency-Key header string false "Unique key making retries return the first response instead of creating another habit"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "Version of the habit"
+// @Header 200 {string} Idempotent-Replayed "true if the response is the stored one of an earlier request with the same key"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Security BearerAuth
+// @Router /habits [post]
+func (srv *Server) CreateHabit(c *gin.Context) {
+	ctx := c.Request.Context()
+	upsert := false
+	if v := c.Query("upsert"); v != "" {
+		var err error
+		if upsert, err = strconv.ParseBool(v); err != nil {
+			c.Error(invalidParam("Invalid upsert, use true or false"))
+			return
+		}
+	}
+	var in HabitInput
+	if !bindHabit(c, &in) {
+		return
+	}
+	h, err := createHabit(srv.client, currentUser(c).ID, &in).Save(ctx)
+	if ent.IsConstraintError(err) && upsert {
+		// Inserting first and looking up on failure is safe against
+		// concurrent creates of the same name
+		h, err = srv.ownedHabits(c).Where(habit.NameKey(schema.HabitNameKey(in.Name))).Only(ctx)
+	} else if ent.IsConstraintError(err) {
+		srv.habitNameTaken(ctx, c, in.Name)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// @Summary Update a habit
+// @Description Replaces every editable field, validated like on create.
+// @Accept json
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param habit body HabitInput true "Habit to update"
+// @Param If-Match header string false "Only update if the habit still has this ETag"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "New version of the habit"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 422 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id} [put]
+func (srv *Server) UpdateHabit(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	var in HabitInput
+	if !bindHabit(c, &in) {
+		return
+	}
+	update := replaceHabit(srv.client.Habit.UpdateOneID(id), &in).
+		Where(habit.OwnerID(currentUser(c).ID))
+	if p := ifMatch(c); p != nil {
+		update.Where(p)
+	}
+	h, err := update.Save(ctx)
+	if ent.IsNotFound(err) {
+		srv.habitWriteFailed(ctx, c, id)
+		return
+	}
+	if ent.IsConstraintError(err) {
+		srv.habitNameTaken(ctx, c, in.Name)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// @Summary Partially update a habit
+// @Description Send a JSON Merge Patch (RFC 7396, application/merge-patch+json or application/json) or a JSON Patch (RFC 6902, application/json-patch+json). Fields set to null or removed are cleared; fields left out of a merge patch are kept.
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param patch body object true "Merge patch or JSON Patch operations"
+// @Param If-Match header string false "Only update if the habit still has this ETag"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "New version of the habit"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 412 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 422 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id} [patch]
+func (srv *Server) PatchHabit(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	query := srv.ownedHabits(c).Where(habit.ID(id))
+	if p := ifMatch(c); p != nil {
+		query.Where(p)
+	}
+	h, err := query.Only(ctx)
+	if ent.IsNotFound(err) {
+		srv.habitWriteFailed(ctx, c, id)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	doc, err := applyHabitPatch(h, c.ContentType(), patch)
+	if errors.Is(err, errUnsupportedPatch) {
+		c.Error(problem(http.StatusUnsupportedMediaType, codeUnsupportedMediaType, "Use "+mergePatchType+" or "+jsonPatchType))
+		return
+	}
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	if err := validateHabit(&doc); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Only the fields that changed are written, and only if nobody else
+	// wrote the habit since it was read
+	update := srv.client.Habit.UpdateOneID(id).
+		Where(habit.OwnerID(currentUser(c).ID), habit.Version(h.Version))
+	if doc.Name != h.Name {
+		update.SetName(doc.Name)
+	}
+	if doc.Description != h.Description {
+		if doc.Description == "" {
+			update.ClearDescription()
+		} else {
+			update.SetDescription(doc.Description)
+		}
+	}
+	if doc.Color != h.Color {
+		if doc.Color == "" {
+			update.ClearColor()
+		} else {
+			update.SetColor(doc.Color)
+		}
+	}
+	if doc.Schedule != h.Schedule || !slices.Equal(doc.Weekdays, h.Weekdays) ||
+		doc.TimesPerPeriod != h.TimesPerPeriod || doc.IntervalDays != h.IntervalDays ||
+		doc.Rrule != h.Rrule {
+		setSchedule(update.Mutation(), &doc)
+	}
+	if doc.Polarity != h.Polarity {
+		update.SetPolarity(doc.Polarity)
+	}
+	if !sameTarget(&doc, h) {
+		setTarget(update.Mutation(), &doc)
+	}
+	if !slices.Equal(doc.Tags, h.Tags) {
+		if doc.Tags != nil {
+			update.SetTags(doc.Tags)
+		} else {
+			update.ClearTags()
+		}
+	}
+	if doc.Archived != h.Archived {
+		update.SetArchived(doc.Archived)
+	}
+	h, err = update.Save(ctx)
+	if ent.IsNotFound(err) {
+		srv.habitWriteFailed(ctx, c, id)
+		return
+	}
+	if ent.IsConstraintError(err) {
+		srv.habitNameTaken(ctx, c, doc.Name)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// @Summary Delete a habit
+// @Description Moves the habit to the trash, from where it can be restored until it is purged. The response holds the event_id to pass to POST /undo.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param If-Match header string false "Only delete if the habit still has this ETag"
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id} [delete]
+func (srv *Server) DeleteHabit(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	del := srv.client.Habit.DeleteOneID(id).
+		Where(habit.OwnerID(currentUser(c).ID))
+	if p := ifMatch(c); p != nil {
+		del.Where(p)
+	}
+	var events []int
+	err = del.Exec(audit.WithEventIDs(ctx, &events))
+	if ent.IsNotFound(err) {
+		srv.habitWriteFailed(ctx, c, id)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Habit moved to trash", "event_id": lastEventID(events)})
+}
+
+// ownedHabits starts a habit query restricted to the authenticated user.
+func (srv *Server) ownedHabits(c *gin.Context) *ent.HabitQuery {
+	return srv.client.Habit.Query().Where(habit.OwnerID(currentUser(c).ID))
+}
+
+func toInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,167 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"api/ent"
+)
+
+// createHabit creates a habit and returns it.
+func (ts *testServer) createHabit(body map[string]any) *ent.Habit {
+	ts.t.Helper()
+	var h ent.Habit
+	ts.call(http.MethodPost, "/habits", body, http.StatusOK, &h)
+	return &h
+}
+
+func TestHabitLifecycle(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "  Read  ", "description": "20 pages"})
+	if h.Name != "Read" || h.Description != "20 pages" || h.Version != 1 {
+		t.Fatalf("got habit %+v, want Read with a description at version 1", h)
+	}
+	path := fmt.Sprintf("/habits/%d", h.ID)
+
+	var got ent.Habit
+	resp := ts.call(http.MethodGet, path, nil, http.StatusOK, &got)
+	etag := resp.Header.Get("ETag")
+	if got.ID != h.ID || etag == "" {
+		t.Fatalf("got habit %d with ETag %q, want habit %d with an ETag", got.ID, etag, h.ID)
+	}
+	ts.call(http.MethodGet, path, nil, http.StatusNotModified, nil, "If-None-Match", etag)
+
+	var replaced ent.Habit
+	ts.call(http.MethodPut, path, map[string]any{"name": "Read more"}, http.StatusOK, &replaced, "If-Match", etag)
+	if replaced.Name != "Read more" || replaced.Description != "" || replaced.Version != 2 {
+		t.Fatalf("got habit %+v after PUT, want Read more without description at version 2", replaced)
+	}
+	// The ETag of version 1 is stale now
+	ts.call(http.MethodDelete, path, nil, http.StatusPreconditionFailed, nil, "If-Match", etag)
+	ts.call(http.MethodPatch, path, map[string]any{"name": "Run"}, http.StatusPreconditionFailed, nil,
+		"Content-Type", "application/merge-patch+json", "If-Match", etag)
+
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, path, nil, http.StatusOK, &deleted)
+	if deleted.EventID == 0 {
+		t.Error("got no event_id for the deletion")
+	}
+	ts.call(http.MethodGet, path, nil, http.StatusNotFound, nil)
+	var trash []map[string]any
+	ts.call(http.MethodGet, "/habits/trash", nil, http.StatusOK, &trash)
+	if len(trash) != 1 {
+		t.Fatalf("got %d habits in the trash, want 1", len(trash))
+	}
+	ts.call(http.MethodPost, path+"/restore", nil, http.StatusOK, nil)
+	ts.call(http.MethodGet, path, nil, http.StatusOK, nil)
+}
+
+func TestPatchHabit(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read", "description": "20 pages"})
+	path := fmt.Sprintf("/habits/%d", h.ID)
+
+	var got ent.Habit
+	ts.call(http.MethodPatch, path, map[string]any{"description": nil}, http.StatusOK, &got,
+		"Content-Type", "application/merge-patch+json")
+	if got.Name != "Read" || got.Description != "" {
+		t.Errorf("got habit %+v after merge patch, want Read without description", got)
+	}
+	ts.call(http.MethodPatch, path, []map[string]any{{"op": "replace", "path": "/name", "value": "Write"}}, http.StatusOK, &got,
+		"Content-Type", "application/json-patch+json")
+	if got.Name != "Write" {
+		t.Errorf("got name %q after JSON patch, want Write", got.Name)
+	}
+	ts.call(http.MethodPatch, path, map[string]any{"name": ""}, http.StatusUnprocessableEntity, nil,
+		"Content-Type", "application/merge-patch+json")
+	ts.call(http.MethodPatch, path, map[string]any{"name": "Run"}, http.StatusUnsupportedMediaType, nil,
+		"Content-Type", "text/plain")
+}
+
+func TestHabitNotFound(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	ts.call(http.MethodGet, "/habits/999", nil, http.StatusNotFound, nil)
+	ts.call(http.MethodGet, "/habits/abc", nil, http.StatusBadRequest, nil)
+
+	// Habits of other users do not exist for this one
+	ts.token = ts.signUp("other@example.com")
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d", h.ID), nil, http.StatusNotFound, nil)
+}
+
+func TestHabitNamesAreUnique(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Éclair"})
+
+	var p struct {
+		Code       string `json:"code"`
+		ExistingID int    `json:"existing_id"`
+	}
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "éCLAIR"}, http.StatusConflict, &p)
+	if p.ExistingID != h.ID {
+		t.Errorf("got existing_id %d, want %d", p.ExistingID, h.ID)
+	}
+	var got ent.Habit
+	ts.call(http.MethodPost, "/habits?upsert=true", map[string]any{"name": "éclair"}, http.StatusOK, &got)
+	if got.ID != h.ID {
+		t.Errorf("got habit %d from the upsert, want %d", got.ID, h.ID)
+	}
+}
+
+func TestCreateHabitValidation(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	tests := []struct {
+		name  string
+		body  map[string]any
+		field string
+	}{
+		{"missing name", map[string]any{}, "name"},
+		{"bad color", map[string]any{"name": "Read", "color": "blue"}, "color"},
+		{"weekly without a quota", map[string]any{"name": "Read", "schedule": "weekly"}, "times_per_period"},
+		{"secondly rrule", map[string]any{"name": "Read", "schedule": "rrule", "rrule": "FREQ=SECONDLY"}, "rrule"},
+		{"rrule with a start", map[string]any{"name": "Read", "schedule": "rrule", "rrule": "DTSTART=20260101T000000Z;FREQ=DAILY"}, "rrule"},
+		{"numeric without target", map[string]any{"name": "Read", "type": "numeric"}, "target"},
+		{"quit with a target", map[string]any{"name": "Smoke", "polarity": "quit", "type": "numeric", "target": 1}, "type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p struct {
+				Errors []struct {
+					Field string `json:"field"`
+				} `json:"errors"`
+			}
+			ts.in(t).call(http.MethodPost, "/habits", tt.body, http.StatusUnprocessableEntity, &p)
+			for _, e := range p.Errors {
+				if e.Field == tt.field {
+					return
+				}
+			}
+			t.Errorf("got errors %+v, want one for %s", p.Errors, tt.field)
+		})
+	}
+}
+
+func TestListHabitsPagination(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	for i := range 5 {
+		ts.createHabit(map[string]any{"name": fmt.Sprintf("Habit %d", i)})
+	}
+	var page []ent.Habit
+	resp := ts.call(http.MethodGet, "/habits?limit=2&sort=name", nil, http.StatusOK, &page)
+	if len(page) != 2 || page[0].Name != "Habit 0" || page[1].Name != "Habit 1" {
+		t.Fatalf("got first page %+v, want Habit 0 and Habit 1", page)
+	}
+	if resp.Header.Get("Link") == "" {
+		t.Error("got no Link header for the next page")
+	}
+	ts.call(http.MethodGet, "/habits?limit=0", nil, http.StatusBadRequest, nil)
+}
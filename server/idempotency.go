@@ -0,0 +1,193 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"api/ent"
+	"api/ent/idempotencykey"
+	"api/ent/predicate"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// idempotencyTTL is how long the response to a request with an
+	// Idempotency-Key is replayed.
+	idempotencyTTL = 24 * time.Hour
+	// idempotencyLease is how long a key stays claimed by a request without
+	// a response, longer than requests take. A process that dies while
+	// handling the request never stores or releases the key.
+	idempotencyLease = time.Minute
+	// maxIdempotencyKeyLength keeps keys to the size of a UUID with room
+	// for a prefix.
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers stored with an idempotency key.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// recordingWriter passes a response through while keeping a copy of its
+// body.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotent makes a POST endpoint safe to retry. The first request with an
+// Idempotency-Key header is handled as usual and its response, unless it is
+// an error, is stored for 24 hours. Retries with the same key, query and
+// body get that response again, marked with Idempotent-Replayed. Reusing a
+// key for another request is a 422, retrying while the first request still
+// runs a 409, until its lease runs out after a minute. Requests without the
+// header are not affected.
+func (srv *Server) Idempotent(c *gin.Context) {
+	key := c.GetHeader("Idempotency-Key")
+	if key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		abort(c, invalidParam("Invalid Idempotency-Key, use at most 255 characters"))
+		return
+	}
+	ctx := c.Request.Context()
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		c.Abort()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	sum := sha256.Sum256([]byte(c.Request.Method + " " + c.Request.URL.Path + "?" + c.Request.URL.RawQuery + "\n" + string(body)))
+	hash := hex.EncodeToString(sum[:])
+	userID := currentUser(c).ID
+
+	// Claim the key, dropping it first if it expired or its lease ran out.
+	// The unique index makes a concurrent retry fail here and find the
+	// first request.
+	_, err = srv.client.IdempotencyKey.Delete().
+		Where(
+			idempotencykey.UserID(userID),
+			idempotencykey.Key(key),
+			expiredIdempotencyKeys(time.Now()),
+		).
+		Exec(ctx)
+	if err != nil {
+		abort(c, err)
+		return
+	}
+	claim, err := srv.client.IdempotencyKey.Create().
+		SetUserID(userID).
+		SetKey(key).
+		SetRequestHash(hash).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		srv.replay(c, userID, key, hash)
+		return
+	}
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	stored := false
+	defer func() {
+		if stored {
+			return
+		}
+		// Failed requests are not replayed, the client may retry them.
+		// This also runs when the handler panics.
+		if err := srv.client.IdempotencyKey.DeleteOneID(claim.ID).Exec(context.WithoutCancel(ctx)); err != nil {
+			slog.Error("releasing idempotency key", "err", err)
+		}
+	}()
+	w := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = w
+	c.Next()
+	c.Writer = w.ResponseWriter
+
+	// Errors are written later by the Problems middleware, so they are
+	// not recorded
+	if !w.Written() || w.Status() >= http.StatusInternalServerError {
+		return
+	}
+	header := map[string]string{}
+	for _, name := range replayedHeaders {
+		if v := w.Header().Get(name); v != "" {
+			header[name] = v
+		}
+	}
+	err = srv.client.IdempotencyKey.UpdateOneID(claim.ID).
+		SetStatus(w.Status()).
+		SetHeader(header).
+		SetBody(w.body.Bytes()).
+		Exec(context.WithoutCancel(ctx))
+	if err != nil {
+		slog.Error("storing idempotent response", "err", err)
+		return
+	}
+	stored = true
+}
+
+// replay answers a request whose Idempotency-Key was already used.
+func (srv *Server) replay(c *gin.Context, userID int, key, hash string) {
+	prev, err := srv.client.IdempotencyKey.Query().
+		Where(idempotencykey.UserID(userID), idempotencykey.Key(key)).
+		Only(c.Request.Context())
+	switch {
+	case ent.IsNotFound(err):
+		// The first request failed and released the key just now
+		abort(c, problem(http.StatusConflict, codeConflict, "A request with this Idempotency-Key just failed, retry it"))
+	case err != nil:
+		abort(c, err)
+	case prev.RequestHash != hash:
+		abort(c, problem(http.StatusUnprocessableEntity, codeIdempotencyKeyReused, "Idempotency-Key was already used for a different request"))
+	case prev.Status == 0:
+		abort(c, problem(http.StatusConflict, codeConflict, "A request with this Idempotency-Key is still being processed"))
+	default:
+		for name, v := range prev.Header {
+			c.Header(name, v)
+		}
+		c.Header("Idempotent-Replayed", "true")
+		c.Status(prev.Status)
+		c.Writer.Write(prev.Body)
+		c.Abort()
+	}
+}
+
+// expiredIdempotencyKeys matches the keys older than 24 hours and the
+// claims without a response whose lease ran out.
+func expiredIdempotencyKeys(now time.Time) predicate.IdempotencyKey {
+	return idempotencykey.Or(
+		idempotencykey.CreatedAtLT(now.Add(-idempotencyTTL)),
+		idempotencykey.And(
+			idempotencykey.StatusIsNil(),
+			idempotencykey.CreatedAtLT(now.Add(-idempotencyLease)),
+		),
+	)
+}
+
+// PurgeIdempotencyKeys forgets the idempotency keys older than 24 hours and
+// the abandoned claims. It returns the number of keys deleted.
+func (srv *Server) PurgeIdempotencyKeys(ctx context.Context) (int, error) {
+	return srv.client.IdempotencyKey.Delete().
+		Where(expiredIdempotencyKeys(time.Now())).
+		Exec(ctx)
+}
@@ -0,0 +1,67 @@
+package server_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"api/ent"
+)
+
+func TestIdempotentCreate(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	body := map[string]any{"name": "Read"}
+	var first, second ent.Habit
+	ts.call(http.MethodPost, "/habits", body, http.StatusOK, &first, "Idempotency-Key", "create-read")
+	resp := ts.call(http.MethodPost, "/habits", body, http.StatusOK, &second, "Idempotency-Key", "create-read")
+	if resp.Header.Get("Idempotent-Replayed") != "true" || second.ID != first.ID {
+		t.Errorf("got habit %d, replayed %q; want habit %d replayed", second.ID, resp.Header.Get("Idempotent-Replayed"), first.ID)
+	}
+	var habits []ent.Habit
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, &habits)
+	if len(habits) != 1 {
+		t.Errorf("got %d habits, want 1", len(habits))
+	}
+
+	// The key identifies the body and the query string
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "Write"}, http.StatusUnprocessableEntity, nil, "Idempotency-Key", "create-read")
+	ts.call(http.MethodPost, "/habits?upsert=true", body, http.StatusUnprocessableEntity, nil, "Idempotency-Key", "create-read")
+}
+
+func TestIdempotencyKeysPerUser(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	body := map[string]any{"name": "Read"}
+	var first, second ent.Habit
+	ts.call(http.MethodPost, "/habits", body, http.StatusOK, &first, "Idempotency-Key", "k")
+	ts.token = ts.signUp("other@example.com")
+	resp := ts.call(http.MethodPost, "/habits", body, http.StatusOK, &second, "Idempotency-Key", "k")
+	if resp.Header.Get("Idempotent-Replayed") != "" || second.ID == first.ID {
+		t.Errorf("got the habit of another user replayed")
+	}
+}
+
+func TestIdempotencyLease(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	ctx := context.Background()
+	user := ts.client.User.Query().OnlyX(ctx)
+	body := map[string]any{"name": "Read"}
+	// Claims of requests that are still running or never finished, as when
+	// the process died, hashed like Idempotent does
+	sum := sha256.Sum256([]byte("POST /habits?\n" + `{"name":"Read"}`))
+	hash := hex.EncodeToString(sum[:])
+	ts.client.IdempotencyKey.Create().SetUserID(user.ID).SetKey("running").SetRequestHash(hash).ExecX(ctx)
+	ts.client.IdempotencyKey.Create().SetUserID(user.ID).SetKey("abandoned").SetRequestHash(hash).SetCreatedAt(time.Now().Add(-2 * time.Minute)).ExecX(ctx)
+
+	ts.call(http.MethodPost, "/habits", body, http.StatusConflict, nil, "Idempotency-Key", "running")
+	ts.call(http.MethodPost, "/habits", body, http.StatusOK, nil, "Idempotency-Key", "abandoned")
+	resp := ts.call(http.MethodPost, "/habits", body, http.StatusOK, nil, "Idempotency-Key", "abandoned")
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("got the retry handled again, want the response of the reclaimed key")
+	}
+}
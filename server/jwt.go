@@ -0,0 +1,55 @@
+package server
+
+import (
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// accessTokenTTL is the lifetime of a JWT access token. Clients renew it
+// with their refresh token.
+const accessTokenTTL = 15 * time.Minute
+
+// jwtSigningKey returns the HMAC key access tokens are signed with.
+// Without a configured secret a random key is generated, which invalidates
+// all access tokens on restart.
+func jwtSigningKey(secret string) ([]byte, error) {
+	if secret != "" {
+		return []byte(secret), nil
+	}
+	slog.Warn("jwt_secret is not configured, using a random signing key")
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	return key, err
+}
+
+// newAccessToken signs an access token for the given user.
+func (srv *Server) newAccessToken(userID int) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   strconv.Itoa(userID),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
+	})
+	return token.SignedString(srv.jwtSecret)
+}
+
+// parseAccessToken verifies an access token and returns its user ID.
+func (srv *Server) parseAccessToken(s string) (int, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(s, &claims, func(*jwt.Token) (any, error) {
+		return srv.jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, errors.New("invalid subject")
+	}
+	return id, nil
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/ent/habit"
+	"api/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Sort keys of the habit list and their default direction.
+var habitSorts = map[string]bool{
+	"created_at": true,
+	"name":       false,
+	"streak":     true,
+}
+
+// habitCursor is the position of the last habit of a page. Clients get it
+// base64 encoded and must treat it as opaque.
+type habitCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func (cur habitCursor) encode() string {
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeHabitCursor(s string) (*habitCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cur habitCursor
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return nil, err
+	}
+	return &cur, nil
+}
+
+// habitListQuery holds the parsed query string of GET /habits.
+type habitListQuery struct {
+	limit  int
+	sort   string
+	desc   bool
+	cursor *habitCursor
+	where  []predicate.Habit
+}
+
+// parseHabitListQuery validates the pagination, sorting and filter
+// parameters. The returned error message is meant for the client.
+func parseHabitListQuery(c *gin.Context) (habitListQuery, error) {
+	q := habitListQuery{limit: defaultPageSize, sort: c.DefaultQuery("sort", "created_at")}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			return q, fmt.Errorf("Invalid limit, use 1 to %d", maxPageSize)
+		}
+		q.limit = n
+	}
+	desc, ok := habitSorts[q.sort]
+	if !ok {
+		return q, errors.New("Invalid sort, use created_at, name or streak")
+	}
+	switch c.Query("order") {
+	case "":
+		q.desc = desc
+	case "asc":
+		q.desc = false
+	case "desc":
+		q.desc = true
+	default:
+		return q, errors.New("Invalid order, use asc or desc")
+	}
+	if v := c.Query("cursor"); v != "" {
+		cur, err := decodeHabitCursor(v)
+		// A cursor only continues the listing it was issued for
+		if err != nil || cur.Sort != q.sort || cur.Desc != q.desc {
+			return q, errors.New("Invalid cursor")
+		}
+		q.cursor = cur
+	}
+
+	if v := c.Query("name"); v != "" {
+		q.where = append(q.where, habit.NameContainsFold(v))
+	}
+	if v := c.Query("created_after"); v != "" {
+		t, err := parseDateParam(v, false)
+		if err != nil {
+			return q, errors.New("Invalid created_after, use RFC 3339 or YYYY-MM-DD")
+		}
+		q.where = append(q.where, habit.CreatedAtGT(t))
+	}
+	if v := c.Query("created_before"); v != "" {
+		t, err := parseDateParam(v, false)
+		if err != nil {
+			return q, errors.New("Invalid created_before, use RFC 3339 or YYYY-MM-DD")
+		}
+		q.where = append(q.where, habit.CreatedAtLT(t))
+	}
+	archived := false
+	if v := c.Query("archived"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return q, errors.New("Invalid archived, use true or false")
+		}
+		archived = b
+	}
+	q.where = append(q.where, habit.Archived(archived))
+	for _, tag := range c.QueryArray("tag") {
+		q.where = append(q.where, func(s *sql.Selector) {
+			s.Where(sqljson.ValueContains(habit.FieldTags, tag))
+		})
+	}
+	return q, nil
+}
+
+// after restricts a query sorted by field and id to the rows following the
+// cursor.
+func (q habitListQuery) after(field string, value any) predicate.Habit {
+	return func(s *sql.Selector) {
+		cmp := sql.GT
+		if q.desc {
+			cmp = sql.LT
+		}
+		s.Where(sql.Or(
+			cmp(s.C(field), value),
+			sql.And(sql.EQ(s.C(field), value), cmp(s.C(habit.FieldID), q.cursor.ID)),
+		))
+	}
+}
+
+// cursorValue parses the sort value stored in the cursor.
+func (q habitListQuery) cursorValue() (any, error) {
+	switch q.sort {
+	case "created_at":
+		return time.Parse(time.RFC3339Nano, q.cursor.Value)
+	case "streak":
+		return strconv.Atoi(q.cursor.Value)
+	default:
+		return q.cursor.Value, nil
+	}
+}
+
+// setNextLink advertises the next page in an RFC 8288 Link header. The
+// link repeats the request with the cursor replaced.
+func setNextLink(c *gin.Context, cursor string) {
+	query := c.Request.URL.Query()
+	query.Set("cursor", cursor)
+	next := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+	c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+}
+
+// normalizeTags trims the tags and drops empty and duplicate ones.
+func normalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t != "" && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+
+	"api/ent"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Media types accepted by PATCH /habits/:id. Plain application/json is
+// treated as a merge patch.
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+// errUnsupportedPatch is returned for bodies that are neither kind of patch.
+var errUnsupportedPatch = errors.New("unsupported patch format")
+
+// newHabitInput returns the editable fields of h, the document patches are
+// applied to.
+func newHabitInput(h *ent.Habit) HabitInput {
+	return HabitInput{
+		Name:            h.Name,
+		Description:     h.Description,
+		Color:           h.Color,
+		Schedule:        h.Schedule,
+		Weekdays:        h.Weekdays,
+		TimesPerPeriod:  h.TimesPerPeriod,
+		IntervalDays:    h.IntervalDays,
+		Rrule:           h.Rrule,
+		Polarity:        h.Polarity,
+		Type:            h.Type,
+		Unit:            h.Unit,
+		Target:          h.Target,
+		TargetDirection: h.TargetDirection,
+		Tags:            h.Tags,
+		Archived:        h.Archived,
+	}
+}
+
+// applyHabitPatch applies an RFC 7396 merge patch or an RFC 6902 JSON
+// Patch, depending on contentType, to the editable fields of h and returns
+// the result. Removing a field or setting it to null clears it. Patches
+// cannot add fields that HabitInput does not have.
+func applyHabitPatch(h *ent.Habit, contentType string, patch []byte) (HabitInput, error) {
+	var doc HabitInput
+	original, err := json.Marshal(newHabitInput(h))
+	if err != nil {
+		return doc, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var patched []byte
+	switch mediaType {
+	case mergePatchType, "application/json":
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case jsonPatchType:
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.DecodePatch(patch); err == nil {
+			patched, err = ops.Apply(original)
+		}
+	default:
+		return doc, errUnsupportedPatch
+	}
+	if err != nil {
+		return doc, fmt.Errorf("invalid patch: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return doc, fmt.Errorf("invalid patch: %v", err)
+	}
+	return doc, nil
+}
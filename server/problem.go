@@ -0,0 +1,288 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"api/ent"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// problemType is the media type of error responses.
+const problemType = "application/problem+json"
+
+// Stable error codes, found in the code member of every Problem.
+const (
+	codeInvalidParameter     = "invalid_parameter"
+	codeMalformedBody        = "malformed_body"
+	codeValidationFailed     = "validation_failed"
+	codeUnauthenticated      = "unauthenticated"
+	codeInvalidCredentials   = "invalid_credentials"
+	codeForbidden            = "forbidden"
+	codeInsufficientScope    = "insufficient_scope"
+	codeNotFound             = "not_found"
+	codeConflict             = "conflict"
+	codeIdempotencyKeyReused = "idempotency_key_reused"
+	codeGone                 = "gone"
+	codePreconditionFailed   = "precondition_failed"
+	codeUnsupportedMediaType = "unsupported_media_type"
+	codeInternal             = "internal"
+)
+
+// Problem is an RFC 7807 problem details object, the body of every error
+// response.
+type Problem struct {
+	// Type is always about:blank; Code identifies the problem instead.
+	Type   string `json:"type" example:"about:blank"`
+	Title  string `json:"title" example:"Not Found"`
+	Status int    `json:"status" example:"404"`
+	Detail string `json:"detail,omitempty" example:"Habit not found"`
+	// Instance is the path of the request.
+	Instance string `json:"instance,omitempty" example:"/habits/42"`
+	// Code is a stable, machine-readable error code.
+	Code string `json:"code" example:"not_found"`
+	// Errors lists the invalid fields of a validation problem.
+	Errors []FieldError `json:"errors,omitempty"`
+	// ExistingID is the habit that already has the name, for conflicts
+	// over a habit name.
+	ExistingID int `json:"existing_id,omitempty"`
+}
+
+// FieldError describes an invalid field of a request body.
+type FieldError struct {
+	Field   string `json:"field" example:"name"`
+	Message string `json:"message" example:"is required"`
+}
+
+// apiError is an error with everything needed to answer the request.
+// Handlers pass it to c.Error and the Problems middleware renders it.
+type apiError struct {
+	status int
+	code   string
+	detail string
+	fields []FieldError
+	// existingID is rendered as the existing_id member.
+	existingID int
+}
+
+func (e *apiError) Error() string {
+	return e.detail
+}
+
+// toProblem returns the problem details of the error for a request to path.
+func (e *apiError) toProblem(path string) Problem {
+	return Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(e.status),
+		Status:     e.status,
+		Detail:     e.detail,
+		Instance:   path,
+		Code:       e.code,
+		Errors:     e.fields,
+		ExistingID: e.existingID,
+	}
+}
+
+// problem returns an error answered with the given status and code.
+func problem(status int, code, detail string) *apiError {
+	return &apiError{status: status, code: code, detail: detail}
+}
+
+// invalidParam reports a path or query parameter that cannot be used.
+func invalidParam(detail string) *apiError {
+	return problem(http.StatusBadRequest, codeInvalidParameter, detail)
+}
+
+// notFound reports a missing resource.
+func notFound(detail string) *apiError {
+	return problem(http.StatusNotFound, codeNotFound, detail)
+}
+
+// invalidField reports a request body field that failed validation.
+func invalidField(field, message string) *apiError {
+	return validationFailed([]FieldError{{Field: field, Message: message}})
+}
+
+// validationFailed reports the invalid fields of a request body.
+func validationFailed(fields []FieldError) *apiError {
+	details := make([]string, len(fields))
+	for i, f := range fields {
+		details[i] = f.Field + " " + f.Message
+	}
+	return &apiError{
+		status: http.StatusUnprocessableEntity,
+		code:   codeValidationFailed,
+		detail: strings.Join(details, ", "),
+		fields: fields,
+	}
+}
+
+func init() {
+	// Name fields in binding errors by their JSON keys
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// bindJSON binds the request body into obj. On failure it records a
+// binding error for the Problems middleware and returns false.
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return false
+	}
+	return true
+}
+
+// Problems renders the last error a handler added with c.Error as an
+// application/problem+json response, unless the handler has already
+// written one. Errors that are not apiErrors are mapped by type, and
+// unexpected ones are logged and hidden from the client.
+func Problems() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		last := c.Errors.Last()
+		err := last.Err
+		var e *apiError
+		if last.IsType(gin.ErrorTypeBind) {
+			e = bodyError(err)
+		} else {
+			e = toAPIError(err)
+		}
+		if e.status == http.StatusInternalServerError {
+			slog.Error("request failed", "method", c.Request.Method, "path", c.Request.URL.Path, "err", err)
+		}
+		body, _ := json.Marshal(e.toProblem(c.Request.URL.Path))
+		c.Data(e.status, problemType, body)
+	}
+}
+
+// abort stops the handler chain with err, for middleware.
+func abort(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
+
+// toAPIError maps the errors of handlers and ent.
+func toAPIError(err error) *apiError {
+	var e *apiError
+	if errors.As(err, &e) {
+		return e
+	}
+	var validationErr *ent.ValidationError
+	if errors.As(err, &validationErr) {
+		return invalidField(validationErr.Name, validationErr.Unwrap().Error())
+	}
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return problem(http.StatusUnauthorized, codeUnauthenticated, "Authentication required")
+	case ent.IsNotFound(err):
+		return notFound("Resource not found")
+	case ent.IsConstraintError(err):
+		return problem(http.StatusConflict, codeConflict, "Conflicts with existing data")
+	}
+	return problem(http.StatusInternalServerError, codeInternal, "Internal server error")
+}
+
+// bodyError maps the errors of reading and binding a request body. They
+// are all the client's fault.
+func bodyError(err error) *apiError {
+	var e *apiError
+	if errors.As(err, &e) {
+		return e
+	}
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		return validationFailed(fieldErrors(verrs))
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return invalidField(typeErr.Field, "must be "+jsonTypeName(typeErr.Type))
+	}
+	if errors.Is(err, io.EOF) {
+		return problem(http.StatusBadRequest, codeMalformedBody, "Request body is empty")
+	}
+	return problem(http.StatusBadRequest, codeMalformedBody, "Invalid request body: "+err.Error())
+}
+
+// fieldErrors describes the failed binding tags of a request body.
+func fieldErrors(verrs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, len(verrs))
+	for i, fe := range verrs {
+		var msg string
+		switch fe.Tag() {
+		case "required":
+			msg = "is required"
+		case "email":
+			msg = "must be an email address"
+		case "min":
+			msg = "must be at least " + fe.Param() + lengthUnit(fe.Kind(), fe.Param())
+		case "max":
+			msg = "must be at most " + fe.Param() + lengthUnit(fe.Kind(), fe.Param())
+		case "oneof":
+			msg = "must be one of " + fe.Param()
+		case "color":
+			msg = "must be a hex color like #1e90ff"
+		default:
+			msg = "is invalid"
+		}
+		// The namespace locates nested fields, like operations[0].op,
+		// after the name of the bound type
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		fields[i] = FieldError{Field: field, Message: msg}
+	}
+	return fields
+}
+
+// lengthUnit is what min and max count for a field of the given kind.
+func lengthUnit(k reflect.Kind, n string) string {
+	unit := ""
+	switch k {
+	case reflect.String:
+		unit = " character"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " item"
+	default:
+		return ""
+	}
+	if n != "1" {
+		unit += "s"
+	}
+	return unit
+}
+
+// jsonTypeName names a Go type the way a JSON client would.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a string"
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"api/ent"
+	"api/ent/habit"
+)
+
+const (
+	// trendWeeks is the number of weeks of slips reported for a habit to
+	// quit. The trend compares their second half with the first.
+	trendWeeks = 8
+
+	slipsImproving = "improving"
+	slipsSteady    = "steady"
+	slipsWorsening = "worsening"
+)
+
+// Relapse summarises the slips of a habit to quit.
+type Relapse struct {
+	// DaysSinceLastSlip counts the days since the last slip, or since the
+	// habit was created if there was none.
+	DaysSinceLastSlip int `json:"days_since_last_slip"`
+	// LongestCleanStretch is the most days between two slips, counting the
+	// time before the first and since the last.
+	LongestCleanStretch int        `json:"longest_clean_stretch"`
+	LastSlip            *time.Time `json:"last_slip,omitempty"`
+	// WeeklySlips counts the slips of each of the last 8 weeks, oldest
+	// first. Weeks end today.
+	WeeklySlips []int `json:"weekly_slips"`
+	// SlipTrend compares the slips of the last 4 weeks with the 4 before:
+	// "improving", "steady" or "worsening". Unset while the habit is
+	// younger than 8 weeks.
+	SlipTrend string `json:"slip_trend,omitempty" enums:"improving,steady,worsening"`
+}
+
+// computeRelapse evaluates the slips of a habit created at createdAt. Days
+// are evaluated in loc.
+func computeRelapse(slips []*ent.Completion, createdAt time.Time, loc *time.Location, now time.Time) Relapse {
+	r := Relapse{WeeklySlips: make([]int, trendWeeks)}
+	today := civilDay(now, loc)
+	start := civilDay(createdAt, loc)
+	days := make(map[time.Time]bool, len(slips))
+	windowStart := today.AddDate(0, 0, 1-7*trendWeeks)
+	for _, cp := range slips {
+		day := civilDay(cp.CompletedAt, loc)
+		days[day] = true
+		if day.Before(start) {
+			start = day
+		}
+		if r.LastSlip == nil || cp.CompletedAt.After(*r.LastSlip) {
+			last := cp.CompletedAt
+			r.LastSlip = &last
+		}
+		if !day.Before(windowStart) && !day.After(today) {
+			r.WeeklySlips[daysBetween(windowStart, day)/7]++
+		}
+	}
+
+	clean := start
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if days[day] {
+			r.LongestCleanStretch = max(r.LongestCleanStretch, daysBetween(clean, day))
+			clean = day
+		}
+	}
+	r.DaysSinceLastSlip = daysBetween(clean, today)
+	r.LongestCleanStretch = max(r.LongestCleanStretch, r.DaysSinceLastSlip)
+
+	if !civilDay(createdAt, loc).After(windowStart) {
+		before, recent := 0, 0
+		for i, n := range r.WeeklySlips {
+			if i < trendWeeks/2 {
+				before += n
+			} else {
+				recent += n
+			}
+		}
+		switch {
+		case recent < before:
+			r.SlipTrend = slipsImproving
+		case recent > before:
+			r.SlipTrend = slipsWorsening
+		default:
+			r.SlipTrend = slipsSteady
+		}
+	}
+	return r
+}
+
+// daysBetween counts the calendar days from one civil day to another.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// normalizePolarity validates the polarity of a habit received from a
+// client and defaults it to build. Habits to quit are daily and boolean,
+// each check-in being a slip. It returns the invalid fields.
+func normalizePolarity(in *HabitInput) []FieldError {
+	if in.Polarity == "" {
+		in.Polarity = habit.PolarityBuild
+	}
+	if err := habit.PolarityValidator(in.Polarity); err != nil {
+		return []FieldError{{Field: "polarity", Message: fmt.Sprintf("has unknown value %q, expected build or quit", in.Polarity)}}
+	}
+	if in.Polarity != habit.PolarityQuit {
+		return nil
+	}
+	var fields []FieldError
+	if in.Schedule != habit.ScheduleDaily {
+		fields = append(fields, FieldError{Field: "schedule", Message: "must be daily for a habit to quit"})
+	}
+	if in.Type != habit.TypeBoolean {
+		fields = append(fields, FieldError{Field: "type", Message: "must be boolean for a habit to quit"})
+	}
+	return fields
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComputeRelapse(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 11, 20, 0, 0, 0, time.UTC)
+	slips := checkinsAt(t, "2026-02-01T22:00:00Z", "2026-02-03T08:00:00Z", "2026-02-03T09:00:00Z")
+
+	r := computeRelapse(slips, created, time.UTC, now)
+	if r.DaysSinceLastSlip != 36 || r.LongestCleanStretch != 36 {
+		t.Errorf("got %d days since the last slip, longest clean stretch %d; want 36, 36", r.DaysSinceLastSlip, r.LongestCleanStretch)
+	}
+	if r.LastSlip == nil || !r.LastSlip.Equal(slips[2].CompletedAt) {
+		t.Errorf("got last slip %v, want %v", r.LastSlip, slips[2].CompletedAt)
+	}
+	// The 8 weeks end today and start on January 15
+	if want := []int{0, 0, 3, 0, 0, 0, 0, 0}; !slices.Equal(r.WeeklySlips, want) {
+		t.Errorf("got weekly slips %v, want %v", r.WeeklySlips, want)
+	}
+	if r.SlipTrend != slipsImproving {
+		t.Errorf("got trend %q, want %q", r.SlipTrend, slipsImproving)
+	}
+
+	// In Berlin, the first slip is on February 2
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	r = computeRelapse(slips, created, berlin, now)
+	if r.LongestCleanStretch != 36 || r.WeeklySlips[2] != 3 {
+		t.Errorf("got longest clean stretch %d and weekly slips %v in Berlin", r.LongestCleanStretch, r.WeeklySlips)
+	}
+}
+
+func TestComputeRelapseWithoutSlips(t *testing.T) {
+	now := time.Date(2026, 3, 11, 20, 0, 0, 0, time.UTC)
+	r := computeRelapse(nil, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), time.UTC, now)
+	if r.DaysSinceLastSlip != 10 || r.LongestCleanStretch != 10 || r.LastSlip != nil {
+		t.Errorf("got %+v, want 10 clean days since the creation", r)
+	}
+	// Too young for a trend
+	if r.SlipTrend != "" || len(r.WeeklySlips) != trendWeeks {
+		t.Errorf("got trend %q over %d weeks, want none over %d", r.SlipTrend, len(r.WeeklySlips), trendWeeks)
+	}
+}
+
+func TestComputeRelapseTrend(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 11, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		slips []string
+		trend string
+	}{
+		{[]string{"2026-02-10T08:00:00Z", "2026-03-02T08:00:00Z"}, slipsSteady},
+		{[]string{"2026-02-10T08:00:00Z", "2026-03-02T08:00:00Z", "2026-03-03T08:00:00Z"}, slipsWorsening},
+		// Slips before the 8 weeks do not count
+		{[]string{"2026-01-05T08:00:00Z", "2026-03-02T08:00:00Z"}, slipsWorsening},
+	}
+	for _, tt := range tests {
+		if r := computeRelapse(checkinsAt(t, tt.slips...), created, time.UTC, now); r.SlipTrend != tt.trend {
+			t.Errorf("got trend %q for slips %v, want %q", r.SlipTrend, tt.slips, tt.trend)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"api/ent"
+	"api/ent/habit"
+
+	"github.com/teambition/rrule-go"
+)
+
+// schedule is the evaluated recurrence of a habit. Day-based schedules
+// (daily, weekdays, interval, rrule) say on which days a habit is due, quota
+// schedules (weekly, monthly) only require a number of check-ins per period.
+type schedule struct {
+	kind     habit.Schedule
+	weekdays map[time.Weekday]bool
+	interval int
+	anchor   time.Time
+	quota    int
+	rule     *rrule.RRule
+}
+
+// newSchedule evaluates the schedule fields of h. The anchor of interval and
+// RRULE schedules is the day the habit was created, in loc.
+func newSchedule(h *ent.Habit, loc *time.Location) (*schedule, error) {
+	s := &schedule{kind: h.Schedule, anchor: civilDay(h.CreatedAt, loc)}
+	switch h.Schedule {
+	case habit.ScheduleWeekdays:
+		s.weekdays = make(map[time.Weekday]bool, len(h.Weekdays))
+		for _, d := range h.Weekdays {
+			s.weekdays[time.Weekday(d)] = true
+		}
+	case habit.ScheduleWeekly, habit.ScheduleMonthly:
+		s.quota = h.TimesPerPeriod
+	case habit.ScheduleInterval:
+		s.interval = h.IntervalDays
+	case habit.ScheduleRrule:
+		rule, err := parseRRule(h.Rrule, s.anchor)
+		if err != nil {
+			return nil, err
+		}
+		s.rule = rule
+	}
+	return s, nil
+}
+
+// isQuota reports whether the schedule is satisfied per period rather than
+// on specific days.
+func (s *schedule) isQuota() bool {
+	return s.quota > 0
+}
+
+// periodStart returns the first day of the quota period containing day.
+// Weeks start on Monday.
+func (s *schedule) periodStart(day time.Time) time.Time {
+	if s.kind == habit.ScheduleMonthly {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// nextPeriod returns the first day of the quota period following start.
+func (s *schedule) nextPeriod(start time.Time) time.Time {
+	if s.kind == habit.ScheduleMonthly {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// dueFunc reports whether a habit is scheduled on the given calendar day.
+type dueFunc func(day time.Time) bool
+
+// everyDay is the dueFunc of daily habits.
+func everyDay(time.Time) bool { return true }
+
+// dueBetween returns a dueFunc for the days in [from, to]. RRULE occurrences
+// are expanded once for the whole range.
+func (s *schedule) dueBetween(from, to time.Time) dueFunc {
+	switch s.kind {
+	case habit.ScheduleWeekdays:
+		return func(day time.Time) bool { return s.weekdays[day.Weekday()] }
+	case habit.ScheduleInterval:
+		return func(day time.Time) bool {
+			if day.Before(s.anchor) {
+				return false
+			}
+			return int(day.Sub(s.anchor).Hours()/24)%s.interval == 0
+		}
+	case habit.ScheduleRrule:
+		occurrences := make(map[time.Time]bool)
+		for _, t := range s.rule.Between(from, to.AddDate(0, 0, 1), true) {
+			occurrences[civilDay(t, t.Location())] = true
+		}
+		return func(day time.Time) bool { return occurrences[day] }
+	default:
+		return everyDay
+	}
+}
+
+// parseRRule parses an RFC 5545 recurrence rule, with or without the
+// "RRULE:" prefix. Rules start on anchor and repeat at most daily, since
+// their occurrences are expanded from the start on every read.
+func parseRRule(s string, anchor time.Time) (*rrule.RRule, error) {
+	opt, err := rrule.StrToROption(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"))
+	if err != nil {
+		return nil, err
+	}
+	switch opt.Freq {
+	case rrule.DAILY, rrule.WEEKLY, rrule.MONTHLY, rrule.YEARLY:
+	default:
+		return nil, fmt.Errorf("FREQ=%s is not supported, habits repeat at most daily", opt.Freq)
+	}
+	if len(opt.Byhour) > 0 || len(opt.Byminute) > 0 || len(opt.Bysecond) > 0 {
+		return nil, errors.New("BYHOUR, BYMINUTE and BYSECOND are not supported, habits repeat at most daily")
+	}
+	if !opt.Dtstart.IsZero() {
+		return nil, errors.New("DTSTART is not supported, rules start on the day the habit is created")
+	}
+	opt.Dtstart = anchor
+	return rrule.NewRRule(*opt)
+}
+
+// normalizeSchedule validates the schedule fields of a habit received from
+// a client, defaults the kind to daily and drops the fields that do not
+// belong to the selected kind. It returns the invalid fields.
+func normalizeSchedule(in *HabitInput) []FieldError {
+	if in.Schedule == "" {
+		in.Schedule = habit.ScheduleDaily
+	}
+	if err := habit.ScheduleValidator(in.Schedule); err != nil {
+		return []FieldError{{Field: "schedule", Message: fmt.Sprintf("has unknown kind %q", in.Schedule)}}
+	}
+	weekdays, times, interval, rule := in.Weekdays, in.TimesPerPeriod, in.IntervalDays, in.Rrule
+	in.Weekdays, in.TimesPerPeriod, in.IntervalDays, in.Rrule = nil, 0, 0, ""
+	switch in.Schedule {
+	case habit.ScheduleWeekdays:
+		if len(weekdays) == 0 {
+			return []FieldError{{Field: "weekdays", Message: "needs at least one weekday"}}
+		}
+		for _, d := range weekdays {
+			if d < 0 || d > 6 {
+				return []FieldError{{Field: "weekdays", Message: fmt.Sprintf("has invalid weekday %d, expected 0 (Sunday) to 6 (Saturday)", d)}}
+			}
+		}
+		slices.Sort(weekdays)
+		in.Weekdays = slices.Compact(weekdays)
+	case habit.ScheduleWeekly:
+		if times < 1 || times > 7 {
+			return []FieldError{{Field: "times_per_period", Message: "must be between 1 and 7 for a weekly schedule"}}
+		}
+		in.TimesPerPeriod = times
+	case habit.ScheduleMonthly:
+		if times < 1 || times > 31 {
+			return []FieldError{{Field: "times_per_period", Message: "must be between 1 and 31 for a monthly schedule"}}
+		}
+		in.TimesPerPeriod = times
+	case habit.ScheduleInterval:
+		if interval < 1 {
+			return []FieldError{{Field: "interval_days", Message: "must be positive for an interval schedule"}}
+		}
+		in.IntervalDays = interval
+	case habit.ScheduleRrule:
+		if _, err := parseRRule(rule, time.Now()); err != nil {
+			return []FieldError{{Field: "rrule", Message: fmt.Sprintf("is invalid: %v", err)}}
+		}
+		in.Rrule = rule
+	}
+	return nil
+}
+
+// setSchedule copies the normalized schedule of in onto a create or update
+// mutation, clearing the fields that are not used.
+func setSchedule(m *ent.HabitMutation, in *HabitInput) {
+	m.SetSchedule(in.Schedule)
+	if in.Weekdays != nil {
+		m.SetWeekdays(in.Weekdays)
+	} else {
+		m.ClearWeekdays()
+	}
+	if in.TimesPerPeriod != 0 {
+		m.SetTimesPerPeriod(in.TimesPerPeriod)
+	} else {
+		m.ClearTimesPerPeriod()
+	}
+	if in.IntervalDays != 0 {
+		m.SetIntervalDays(in.IntervalDays)
+	} else {
+		m.ClearIntervalDays()
+	}
+	if in.Rrule != "" {
+		m.SetRrule(in.Rrule)
+	} else {
+		m.ClearRrule()
+	}
+}
+
+// isDue reports whether the habit is due on day, given its daily check-in
+// totals. A quota habit stays due until its check-ins on the days of the
+// period before day meet the quota.
+func (s *schedule) isDue(day time.Time, tgt target, totals map[time.Time]float64) bool {
+	if !s.isQuota() {
+		return s.dueBetween(day, day)(day)
+	}
+	return !tgt.periodMet(totals, s.quota, s.periodStart(day), day)
+}
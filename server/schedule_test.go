@@ -0,0 +1,137 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"api/ent"
+	"api/ent/habit"
+)
+
+// day returns midnight UTC of a YYYY-MM-DD date, as civilDay does.
+func day(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestParseRRule(t *testing.T) {
+	anchor := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	valid := []string{
+		"FREQ=DAILY",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE",
+		"FREQ=MONTHLY;BYMONTHDAY=1,15",
+		"FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=1",
+	}
+	for _, s := range valid {
+		rule, err := parseRRule(s, anchor)
+		if err != nil {
+			t.Errorf("parseRRule(%q): %v", s, err)
+			continue
+		}
+		if !rule.GetDTStart().Equal(anchor) {
+			t.Errorf("parseRRule(%q) starts on %v, want %v", s, rule.GetDTStart(), anchor)
+		}
+	}
+	invalid := []string{
+		"every day",
+		"FREQ=SECONDLY",
+		"FREQ=MINUTELY;INTERVAL=5",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;BYHOUR=8,20",
+		"FREQ=WEEKLY;BYMINUTE=0",
+		"FREQ=DAILY;BYSECOND=0,30",
+		"DTSTART=20260101T000000Z;FREQ=DAILY",
+	}
+	for _, s := range invalid {
+		if _, err := parseRRule(s, anchor); err == nil {
+			t.Errorf("parseRRule(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestDueBetween(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		habit ent.Habit
+		due   []string
+	}{
+		{"daily", ent.Habit{Schedule: habit.ScheduleDaily}, []string{"2026-02-27", "2026-02-28", "2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05"}},
+		{"weekdays", ent.Habit{Schedule: habit.ScheduleWeekdays, Weekdays: []int{0, 3}}, []string{"2026-03-01", "2026-03-04"}},
+		{"interval", ent.Habit{Schedule: habit.ScheduleInterval, IntervalDays: 3}, []string{"2026-03-01", "2026-03-04"}},
+		{"rrule", ent.Habit{Schedule: habit.ScheduleRrule, Rrule: "FREQ=WEEKLY;BYDAY=MO,TH"}, []string{"2026-03-02", "2026-03-05"}},
+		{"quota", ent.Habit{Schedule: habit.ScheduleWeekly, TimesPerPeriod: 3}, []string{"2026-02-27", "2026-02-28", "2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05"}},
+	}
+	from, to := day(t, "2026-02-27"), day(t, "2026-03-05")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.habit.CreatedAt = created
+			sched, err := newSchedule(&tt.habit, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make(map[time.Time]bool)
+			for _, d := range tt.due {
+				want[day(t, d)] = true
+			}
+			due := sched.dueBetween(from, to)
+			for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+				if due(d) != want[d] {
+					t.Errorf("got due %t on %s, want %t", due(d), d.Format(time.DateOnly), want[d])
+				}
+			}
+		})
+	}
+}
+
+func TestQuotaPeriods(t *testing.T) {
+	weekly := &schedule{kind: habit.ScheduleWeekly, quota: 2}
+	monthly := &schedule{kind: habit.ScheduleMonthly, quota: 2}
+	tests := []struct {
+		sched       *schedule
+		day         string
+		start, next string
+	}{
+		{weekly, "2026-03-09", "2026-03-09", "2026-03-16"},
+		{weekly, "2026-03-15", "2026-03-09", "2026-03-16"},
+		{weekly, "2026-01-01", "2025-12-29", "2026-01-05"},
+		{monthly, "2026-03-31", "2026-03-01", "2026-04-01"},
+		{monthly, "2026-12-01", "2026-12-01", "2027-01-01"},
+	}
+	for _, tt := range tests {
+		start := tt.sched.periodStart(day(t, tt.day))
+		next := tt.sched.nextPeriod(start)
+		if start.Format(time.DateOnly) != tt.start || next.Format(time.DateOnly) != tt.next {
+			t.Errorf("got %s %s to %s for %s, want %s to %s", tt.sched.kind, start.Format(time.DateOnly), next.Format(time.DateOnly), tt.day, tt.start, tt.next)
+		}
+	}
+}
+
+func TestIsDue(t *testing.T) {
+	weekly := &schedule{kind: habit.ScheduleWeekly, quota: 2}
+	tgt := target{value: 1}
+	totals := map[time.Time]float64{
+		day(t, "2026-03-06"): 1,
+		day(t, "2026-03-09"): 1,
+		day(t, "2026-03-10"): 1,
+	}
+	for d, want := range map[string]bool{
+		// Check-ins of the day itself and of the week before do not count
+		"2026-03-09": true,
+		"2026-03-10": true,
+		"2026-03-11": false,
+		"2026-03-16": true,
+	} {
+		if got := weekly.isDue(day(t, d), tgt, totals); got != want {
+			t.Errorf("got due %t on %s, want %t", got, d, want)
+		}
+	}
+	weekdays := &schedule{kind: habit.ScheduleWeekdays, weekdays: map[time.Weekday]bool{time.Monday: true}}
+	if !weekdays.isDue(day(t, "2026-03-09"), tgt, totals) || weekdays.isDue(day(t, "2026-03-10"), tgt, totals) {
+		t.Error("got a weekday schedule due on the wrong days")
+	}
+}
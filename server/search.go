@@ -0,0 +1,277 @@
+package server
+
+import (
+	"cmp"
+	"html"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"api/ent/completion"
+	"api/ent/habit"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchTerms     = 8
+	// searchVector is the generated tsvector column that the Postgres
+	// migrations add to habits and completions.
+	searchVector = "search_vector"
+	// Snippets are highlighted with these markers, which stripMarkers
+	// removes from user text on input, and turned into <mark> elements
+	// after HTML escaping.
+	markStart = "\x01"
+	markStop  = "\x02"
+	// snippetRunes is the length of the fallback snippets.
+	snippetRunes = 80
+)
+
+// tsHeadlineOptions configures ts_headline to mark the matches.
+var tsHeadlineOptions = `StartSel="` + markStart + `", StopSel="` + markStop + `", MinWords=8, MaxWords=20`
+
+var markerStripper = strings.NewReplacer(markStart, "", markStop, "")
+
+// stripMarkers removes the snippet markers from text received from a
+// client, so that they only ever delimit matches.
+func stripMarkers(text string) string {
+	return markerStripper.Replace(text)
+}
+
+// SearchResult is a habit or check-in matching a search.
+type SearchResult struct {
+	// Type is habit or checkin.
+	Type      string `json:"type"`
+	HabitID   int    `json:"habit_id"`
+	HabitName string `json:"habit_name"`
+	CheckinID int    `json:"checkin_id,omitempty"`
+	// Rank orders the results, higher is more relevant.
+	Rank float64 `json:"rank"`
+	// Snippet is an HTML excerpt with the matches wrapped in <mark>.
+	Snippet string `json:"snippet"`
+}
+
+// searchRow is a row scanned by the search queries.
+type searchRow struct {
+	ID      int     `json:"id"`
+	HabitID int     `json:"habit_id"`
+	Name    string  `json:"name"`
+	Text    string  `json:"text"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// @Summary Search habits and check-in notes
+// @Description Matches habit names and descriptions and check-in notes. Postgres ranks with full-text search, other databases match every word as a substring.
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results, 1 to 100" default(20)
+// @Success 200 {array} SearchResult
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Security BearerAuth
+// @Router /search [get]
+func (srv *Server) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+	q := strings.TrimSpace(c.Query("q"))
+	terms := strings.Fields(q)
+	if len(terms) == 0 {
+		c.Error(invalidParam("Query cannot be empty"))
+		return
+	}
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	limit := defaultSearchLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			c.Error(invalidParam("Invalid limit, use 1 to 100"))
+			return
+		}
+		limit = n
+	}
+	uid := currentUser(c).ID
+
+	var fullText bool
+	var habits, checkins []searchRow
+	err := srv.client.Habit.Query().
+		Where(habit.OwnerID(uid)).
+		Select(habit.FieldID, habit.FieldName).
+		Modify(func(s *sql.Selector) {
+			fullText = s.Dialect() == dialect.Postgres
+			s.AppendSelectExprAs(sql.Expr(s.C(habit.FieldID)), "habit_id")
+			doc := "concat_ws(' ', " + s.C(habit.FieldName) + ", " + s.C(habit.FieldDescription) + ")"
+			searchSelect(s, q, terms, doc, map[string]int{habit.FieldName: 2, habit.FieldDescription: 1}, limit)
+		}).
+		Scan(ctx, &habits)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = srv.client.Completion.Query().
+		Where(completion.HasHabitWith(habit.OwnerID(uid), habit.DeletedAtIsNil())).
+		Select(completion.FieldID, completion.FieldHabitID).
+		Modify(func(s *sql.Selector) {
+			s.AppendSelectExprAs(sql.Expr("''"), "name")
+			doc := "coalesce(" + s.C(completion.FieldNote) + ", '')"
+			searchSelect(s, q, terms, doc, map[string]int{completion.FieldNote: 1}, limit)
+		}).
+		Scan(ctx, &checkins)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Check-ins only carry the habit ID, so the names come from a second query
+	names := make(map[int]string, len(habits))
+	var missing []int
+	for _, r := range habits {
+		names[r.ID] = r.Name
+	}
+	for _, r := range checkins {
+		if _, ok := names[r.HabitID]; !ok {
+			missing = append(missing, r.HabitID)
+		}
+	}
+	if len(missing) > 0 {
+		named, err := srv.client.Habit.Query().
+			Where(habit.IDIn(missing...)).
+			Select(habit.FieldID, habit.FieldName).
+			All(ctx)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		for _, h := range named {
+			names[h.ID] = h.Name
+		}
+	}
+
+	results := make([]SearchResult, 0, len(habits)+len(checkins))
+	for _, r := range habits {
+		results = append(results, newSearchResult("habit", r, 0, names, terms, fullText))
+	}
+	for _, r := range checkins {
+		results = append(results, newSearchResult("checkin", r, r.ID, names, terms, fullText))
+	}
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Compare(b.Rank, a.Rank)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// searchSelect restricts s to the rows matching the query and selects their
+// rank and text. On Postgres the generated search_vector column is matched
+// and ranked, and ts_headline builds the snippet from doc. Elsewhere every
+// term must appear in one of the weighted columns, the rank adds up the
+// weights of the matching columns and the snippet is left to Go.
+func searchSelect(s *sql.Selector, q string, terms []string, doc string, weights map[string]int, limit int) {
+	if s.Dialect() == dialect.Postgres {
+		tsquery := func(b *sql.Builder) {
+			b.WriteString("websearch_to_tsquery('simple', ").Arg(q).WriteString(")")
+		}
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.WriteString(s.C(searchVector)).WriteString(" @@ ")
+			tsquery(b)
+		}))
+		s.AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("ts_rank(").WriteString(s.C(searchVector)).WriteString(", ")
+			tsquery(b)
+			b.WriteString(")")
+		}), "rank")
+		s.AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("ts_headline('simple', ").WriteString(doc).WriteString(", ")
+			tsquery(b)
+			b.WriteString(", ").Arg(tsHeadlineOptions).WriteString(")")
+		}), "snippet")
+		s.AppendSelectExprAs(sql.Expr("''"), "text")
+	} else {
+		cols := make([]string, 0, len(weights))
+		for col := range weights {
+			cols = append(cols, col)
+		}
+		slices.Sort(cols)
+		for _, t := range terms {
+			var matches []*sql.Predicate
+			for _, col := range cols {
+				matches = append(matches, sql.ContainsFold(s.C(col), t))
+			}
+			s.Where(sql.Or(matches...))
+		}
+		s.AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("0")
+			for _, t := range terms {
+				for _, col := range cols {
+					b.WriteString(" + (instr(lower(coalesce(").WriteString(s.C(col)).WriteString(", '')), lower(").Arg(t).WriteString(")) > 0) * ")
+					b.WriteString(strconv.Itoa(weights[col]))
+				}
+			}
+		}), "rank")
+		s.AppendSelectExprAs(sql.Expr("''"), "snippet")
+		s.AppendSelectExprAs(sql.Expr(doc), "text")
+	}
+	s.OrderExpr(sql.Expr("rank DESC"))
+	s.Limit(limit)
+}
+
+func newSearchResult(typ string, r searchRow, checkinID int, names map[int]string, terms []string, fullText bool) SearchResult {
+	snippet := r.Snippet
+	if !fullText {
+		snippet = highlight(r.Text, terms)
+	}
+	return SearchResult{
+		Type:      typ,
+		HabitID:   r.HabitID,
+		HabitName: names[r.HabitID],
+		CheckinID: checkinID,
+		Rank:      r.Rank,
+		Snippet:   renderSnippet(snippet),
+	}
+}
+
+// highlight marks the terms in text, cut to an excerpt around the first
+// match.
+func highlight(text string, terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	re := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+	text = stripMarkers(text)
+	if utf8.RuneCountInString(text) > snippetRunes {
+		start := 0
+		if loc := re.FindStringIndex(text); loc != nil {
+			// Keep some context before the match
+			start = max(0, utf8.RuneCountInString(text[:loc[0]])-snippetRunes/4)
+		}
+		runes := []rune(text)
+		end := min(len(runes), start+snippetRunes)
+		excerpt := string(runes[start:end])
+		if start > 0 {
+			excerpt = "…" + excerpt
+		}
+		if end < len(runes) {
+			excerpt += "…"
+		}
+		text = excerpt
+	}
+	return re.ReplaceAllString(text, markStart+"$0"+markStop)
+}
+
+// renderSnippet escapes a marked snippet for HTML and turns the markers into
+// <mark> elements.
+func renderSnippet(s string) string {
+	s = html.EscapeString(s)
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(s)
+}
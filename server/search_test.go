@@ -0,0 +1,49 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"api/server"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Morning run", "description": "Around the <park>"})
+	ts.checkIn(h.ID, map[string]any{"note": "Slow run in the rain"})
+	ts.createHabit(map[string]any{"name": "Read"})
+
+	var results []server.SearchResult
+	ts.call(http.MethodGet, "/search?q=run", nil, http.StatusOK, &results)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want the habit and the check-in", len(results))
+	}
+	for _, r := range results {
+		if r.HabitID != h.ID || r.HabitName != "Morning run" {
+			t.Errorf("got result %+v, want one of habit %d", r, h.ID)
+		}
+		if !strings.Contains(r.Snippet, "<mark>") {
+			t.Errorf("got snippet %q, want the match marked", r.Snippet)
+		}
+	}
+	ts.call(http.MethodGet, "/search?q=%20", nil, http.StatusBadRequest, nil)
+}
+
+func TestSearchEscapesUserText(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	// The control characters delimit the matches internally
+	ts.createHabit(map[string]any{"name": "Run \x01<b>fast</b>\x02"})
+
+	var results []server.SearchResult
+	ts.call(http.MethodGet, "/search?q=fast", nil, http.StatusOK, &results)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	snippet := results[0].Snippet
+	if strings.Contains(snippet, "<b>") || strings.Count(snippet, "<mark>") != 1 {
+		t.Errorf("got snippet %q, want the user text escaped and only the match marked", snippet)
+	}
+}
@@ -0,0 +1,113 @@
+// Package server implements the habit tracker HTTP API on top of an ent
+// client.
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"api/config"
+	"api/ent"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// Server serves the API for the habits stored through its ent client. It
+// is an http.Handler, so it can be mounted in other programs or driven by
+// httptest.
+type Server struct {
+	client    *ent.Client
+	cfg       config.Config
+	jwtSecret []byte
+	engine    *gin.Engine
+}
+
+// New returns a Server using the given client. Only the HTTP related
+// settings of cfg are used; the database is managed by the caller.
+func New(client *ent.Client, cfg config.Config) (*Server, error) {
+	key, err := jwtSigningKey(cfg.JWTSecret)
+	if err != nil {
+		return nil, err
+	}
+	srv := &Server{client: client, cfg: cfg, jwtSecret: key}
+	level, err := cfg.SlogLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	r := gin.New()
+	// Request logs are info level
+	if level <= slog.LevelInfo {
+		r.Use(gin.Logger())
+	}
+	// Errors, including panics, are answered with problem details
+	r.Use(Problems())
+	r.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		abort(c, fmt.Errorf("panic: %v", err))
+	}))
+	if len(cfg.CORSOrigins) > 0 {
+		r.Use(CORS(cfg.CORSOrigins))
+	}
+	if cfg.TemplateGlob != "" {
+		r.LoadHTMLGlob(cfg.TemplateGlob)
+		r.GET("/", func(c *gin.Context) {
+			c.HTML(http.StatusOK, "index.html", nil)
+		})
+	}
+
+	// Swagger UI endpoint
+	if cfg.Features.Swagger {
+		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	// Register routes with named handlers
+	if cfg.Features.Registration {
+		r.POST("/auth/register", srv.Register)
+	}
+	r.POST("/auth/login", srv.Login)
+	r.POST("/auth/refresh", srv.Refresh)
+	r.POST("/auth/logout", srv.Logout)
+	r.POST("/auth/session", srv.CreateCookieSession)
+	r.DELETE("/auth/session", srv.DeleteCookieSession)
+
+	// Everything below requires a bearer token or a session cookie
+	auth := r.Group("/", srv.RequireAuth)
+	auth.GET("/auth/me", srv.GetMe)
+	auth.GET("/habits", RequireScope(scopeHabitsRead), srv.GetHabits)
+	auth.GET("/habits/due", RequireScope(scopeHabitsRead), srv.GetDueHabits)
+	auth.GET("/habits/trash", RequireScope(scopeHabitsRead), srv.GetTrash)
+	auth.POST("/habits", RequireScope(scopeHabitsWrite), srv.Idempotent, srv.CreateHabit)
+	auth.POST("/habits:method", RequireScope(scopeHabitsWrite), srv.Idempotent, srv.habitsMethod)
+	auth.GET("/habits/:id", RequireScope(scopeHabitsRead), srv.GetHabit)
+	auth.PUT("/habits/:id", RequireScope(scopeHabitsWrite), srv.UpdateHabit)
+	auth.PATCH("/habits/:id", RequireScope(scopeHabitsWrite), srv.PatchHabit)
+	auth.DELETE("/habits/:id", RequireScope(scopeHabitsWrite), srv.DeleteHabit)
+	auth.POST("/habits/:id/restore", RequireScope(scopeHabitsWrite), srv.RestoreHabit)
+	auth.POST("/habits/:id/archive", RequireScope(scopeHabitsWrite), srv.ArchiveHabit)
+	auth.POST("/habits/:id/unarchive", RequireScope(scopeHabitsWrite), srv.UnarchiveHabit)
+	auth.POST("/habits/:id/checkins", RequireScope(scopeCheckinsWrite), srv.Idempotent, srv.CreateCheckin)
+	auth.GET("/habits/:id/checkins", RequireScope(scopeCheckinsRead), srv.GetCheckins)
+	auth.GET("/habits/:id/stats", RequireScope(scopeHabitsRead), RequireScope(scopeCheckinsRead), srv.GetHabitStats)
+	auth.DELETE("/habits/:id/checkins/:checkinId", RequireScope(scopeCheckinsWrite), srv.DeleteCheckin)
+	auth.GET("/search", RequireScope(scopeHabitsRead), RequireScope(scopeCheckinsRead), srv.Search)
+	auth.GET("/audit", RequireScope(scopeHabitsRead), RequireScope(scopeCheckinsRead), srv.GetAuditEvents)
+	auth.POST("/undo/:eventId", RequireScope(scopeHabitsWrite), RequireScope(scopeCheckinsWrite), srv.Undo)
+	auth.POST("/tokens", RejectAPITokens, srv.CreateToken)
+	auth.GET("/tokens", RejectAPITokens, srv.GetTokens)
+	auth.DELETE("/tokens/:id", RejectAPITokens, srv.DeleteToken)
+
+	r.NoRoute(func(c *gin.Context) {
+		c.Error(notFound("No such endpoint"))
+	})
+
+	srv.engine = r
+	return srv, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.engine.ServeHTTP(w, r)
+}
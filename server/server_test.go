@@ -0,0 +1,280 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"api/config"
+	"api/database"
+	"api/ent"
+	"api/ent/enttest"
+	"api/server"
+
+	"entgo.io/ent/dialect"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testServer is a server started with httptest and a user signed in to it.
+type testServer struct {
+	t      *testing.T
+	url    string
+	srv    *server.Server
+	client *ent.Client
+	token  string
+}
+
+// newTestServer starts a server over a database of its own, opened by
+// openTestDatabase, so that tests can run in parallel. Options adjust the
+// default configuration.
+func newTestServer(t *testing.T, opts ...func(*config.Config)) *testServer {
+	t.Helper()
+	return serve(t, openTestDatabase(t), opts...)
+}
+
+// openTestDatabase opens a database for a test with database.Open, like
+// main.go. It is a SQLite file in a temporary directory created from the ent
+// schema. Unlike a shared in-memory database, concurrent writers wait for the
+// lock instead of failing with "database table is locked".
+// When TEST_POSTGRES_DSN is set, it is instead a schema of its own in that
+// Postgres database, created by the migrations and dropped afterwards.
+func openTestDatabase(t *testing.T) *ent.Client {
+	t.Helper()
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		return openPostgres(t, dsn)
+	}
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_txlock=immediate"
+	client, err := database.Open(database.SQLite, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// postgresSchemas numbers the Postgres schemas of the tests.
+var postgresSchemas atomic.Int64
+
+// openPostgres creates a schema for a test in the Postgres database at dsn
+// and migrates it.
+func openPostgres(t *testing.T, dsn string) *ent.Client {
+	t.Helper()
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := fmt.Sprintf("test_%d_%d", os.Getpid(), postgresSchemas.Add(1))
+	if _, err := db.Exec("CREATE SCHEMA " + name); err != nil {
+		db.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Exec("DROP SCHEMA " + name + " CASCADE"); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+	// lib/pq passes unknown settings on to the server
+	if strings.Contains(dsn, "://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + "search_path=" + name
+	} else {
+		dsn += " search_path=" + name
+	}
+	mg, err := database.NewMigrator(database.Postgres, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mg.Close()
+	if err := mg.Up(); err != nil {
+		t.Fatal(err)
+	}
+	client, err := database.Open(database.Postgres, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// serve starts a server over client and signs in a new user.
+func serve(t *testing.T, client *ent.Client, opts ...func(*config.Config)) *testServer {
+	t.Helper()
+	cfg := config.Default()
+	cfg.TemplateGlob = "../templates/*"
+	cfg.GinMode = "test"
+	// Without request logs
+	cfg.LogLevel = "warn"
+	cfg.JWTSecret = "test-secret"
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	srv, err := server.New(client, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := httptest.NewServer(srv)
+	t.Cleanup(hs.Close)
+	ts := &testServer{t: t, url: hs.URL, srv: srv, client: client}
+	if cfg.Features.Registration {
+		ts.token = ts.signUp("user@example.com")
+	}
+	return ts
+}
+
+// in returns a copy of ts that reports to the subtest t.
+func (ts *testServer) in(t *testing.T) *testServer {
+	sub := *ts
+	sub.t = t
+	return &sub
+}
+
+// signUp registers a user and returns an access token for them.
+func (ts *testServer) signUp(email string) string {
+	ts.t.Helper()
+	creds := map[string]string{"email": email, "password": "password1"}
+	ts.call(http.MethodPost, "/auth/register", creds, http.StatusOK, nil)
+	var tokens struct {
+		AccessToken string `json:"access_token"`
+	}
+	ts.call(http.MethodPost, "/auth/login", creds, http.StatusOK, &tokens)
+	return tokens.AccessToken
+}
+
+// request sends a request as the signed-in user, with body encoded as JSON
+// unless it is nil. Headers are given as name and value pairs.
+func (ts *testServer) request(method, path string, body any, headers ...string) *http.Response {
+	ts.t.Helper()
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			ts.t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, ts.url+path, r)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ts.token != "" {
+		req.Header.Set("Authorization", "Bearer "+ts.token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	return resp
+}
+
+// call sends a request like request, fails the test unless the response
+// has status want and decodes the JSON body into out if it is not nil.
+func (ts *testServer) call(method, path string, body any, want int, out any, headers ...string) *http.Response {
+	ts.t.Helper()
+	resp := ts.request(method, path, body, headers...)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	if resp.StatusCode != want {
+		ts.t.Fatalf("%s %s: got status %d, want %d: %s", method, path, resp.StatusCode, want, b)
+	}
+	if out != nil {
+		if err := json.Unmarshal(b, out); err != nil {
+			ts.t.Fatalf("%s %s: decoding %s: %v", method, path, b, err)
+		}
+	}
+	return resp
+}
+
+func TestNewOverEnttestClient(t *testing.T) {
+	t.Parallel()
+	client := enttest.Open(t, dialect.SQLite, "file:"+filepath.Join(t.TempDir(), "test.db")+"?_fk=1")
+	t.Cleanup(func() { client.Close() })
+	ts := serve(t, client)
+	ts.call(http.MethodPost, "/habits", map[string]string{"name": "Read"}, http.StatusOK, nil)
+	if n := client.Habit.Query().CountX(context.Background()); n != 1 {
+		t.Errorf("got %d habits in the database, want 1", n)
+	}
+}
+
+func TestNewServesWebUI(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	resp := ts.call(http.MethodGet, "/", nil, http.StatusOK, nil)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("got Content-Type %q, want text/html", ct)
+	}
+}
+
+func TestNewWithoutTemplates(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.TemplateGlob = "" })
+	ts.call(http.MethodGet, "/", nil, http.StatusNotFound, nil)
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, nil)
+}
+
+func TestNewWithoutRegistration(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.Features.Registration = false })
+	creds := map[string]string{"email": "user@example.com", "password": "password1"}
+	ts.call(http.MethodPost, "/auth/register", creds, http.StatusNotFound, nil)
+}
+
+func TestNewIsolatesDatabases(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"first", "second"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Each server has the same user and a single habit
+			ts := newTestServer(t)
+			ts.call(http.MethodPost, "/habits", map[string]string{"name": "Read"}, http.StatusOK, nil)
+			var habits []map[string]any
+			ts.call(http.MethodGet, "/habits", nil, http.StatusOK, &habits)
+			if len(habits) != 1 {
+				t.Fatalf("got %d habits, want 1", len(habits))
+			}
+		})
+	}
+}
+
+func TestRequiresAuthentication(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	ts.token = ""
+	var p struct {
+		Status int    `json:"status"`
+		Code   string `json:"code"`
+	}
+	ts.call(http.MethodGet, "/habits", nil, http.StatusUnauthorized, &p)
+	if p.Status != http.StatusUnauthorized || p.Code == "" {
+		t.Errorf("got problem %+v, want a 401 with a code", p)
+	}
+}
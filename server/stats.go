@@ -0,0 +1,449 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/ent"
+	"api/ent/completion"
+	"api/ent/habit"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gin-gonic/gin"
+)
+
+// Granularities of the habit statistics.
+const (
+	granularityDay   = "day"
+	granularityWeek  = "week"
+	granularityMonth = "month"
+)
+
+// maxStatsDays limits the range of the habit statistics to about five years.
+const maxStatsDays = 5 * 366
+
+// movingAverageWindow is the number of periods, up to and including the
+// current one, the moving average of each granularity covers.
+var movingAverageWindow = map[string]int{
+	granularityDay:   7,
+	granularityWeek:  4,
+	granularityMonth: 3,
+}
+
+// PeriodStats are the statistics of a day, week or month. Weeks start on
+// Monday. The first and last period are cut to the requested range.
+type PeriodStats struct {
+	// Start is the first day of the period.
+	Start string `json:"start" example:"2026-10-12"`
+	// Completions counts the check-ins.
+	Completions int `json:"completions"`
+	// DoneDays counts the days whose check-ins meet the target, or for a
+	// habit to quit the days without a slip.
+	DoneDays int `json:"done_days"`
+	// Rate is DoneDays divided by the days the habit was due, from 0 to 1.
+	// Unset if the habit was not due in the period.
+	Rate *float64 `json:"rate,omitempty" example:"0.75"`
+	// MovingAverage is the mean rate of the last 7 days, 4 weeks or 3
+	// months up to this period.
+	MovingAverage *float64 `json:"moving_average,omitempty" example:"0.8"`
+	// AverageValue is the mean daily total of a numeric or duration habit
+	// over the days with check-ins.
+	AverageValue *float64 `json:"average_value,omitempty" example:"2.5"`
+}
+
+// WeekdayStats is the completion rate of a day of the week.
+type WeekdayStats struct {
+	// Weekday is 0 for Sunday to 6 for Saturday.
+	Weekday int     `json:"weekday" example:"1"`
+	Rate    float64 `json:"rate" example:"0.9"`
+}
+
+// HabitStats summarises the check-ins of a habit over a range of days.
+type HabitStats struct {
+	From        string `json:"from" example:"2026-01-01"`
+	To          string `json:"to" example:"2026-12-31"`
+	Granularity string `json:"granularity" example:"week"`
+	// TotalCompletions counts the check-ins in the range.
+	TotalCompletions int `json:"total_completions"`
+	// DoneDays and Rate are those of the whole range.
+	DoneDays     int      `json:"done_days"`
+	Rate         *float64 `json:"rate,omitempty"`
+	AverageValue *float64 `json:"average_value,omitempty"`
+	// BestWeekday and WorstWeekday have the highest and lowest rate of the
+	// weekdays the habit was due on. Unset if the range has no check-ins.
+	BestWeekday  *WeekdayStats `json:"best_weekday,omitempty"`
+	WorstWeekday *WeekdayStats `json:"worst_weekday,omitempty"`
+	Periods      []PeriodStats `json:"periods"`
+}
+
+// statsRow is a group of days aggregated by the database.
+type statsRow struct {
+	Bucket   string  `json:"bucket"`
+	Days     int     `json:"days"`
+	Checkins int     `json:"checkins"`
+	Total    float64 `json:"total"`
+	Done     int     `json:"done"`
+}
+
+// statsTally adds up the days of a period or weekday.
+type statsTally struct {
+	statsRow
+	// due is the number of days the habit was due, fractional for quota
+	// schedules.
+	due float64
+}
+
+// done is the number of done days. For a habit to quit that is the days
+// it was tracked without a slip, for an at most target the days without
+// check-ins exceeding it.
+func (t statsTally) done(quit, atMost bool) int {
+	switch {
+	case quit:
+		return max(0, int(t.due)-t.Days)
+	case atMost:
+		return max(0, int(t.due)-(t.Days-t.Done))
+	}
+	return t.Done
+}
+
+// rate is the share of the due days that were done, or nil if none was due.
+func (t statsTally) rate(quit, atMost bool) *float64 {
+	if t.due == 0 {
+		return nil
+	}
+	r := min(1, float64(t.done(quit, atMost))/t.due)
+	return &r
+}
+
+// averageValue is the mean daily total of a measured habit.
+func (t statsTally) averageValue(measured bool) *float64 {
+	if !measured || t.Days == 0 {
+		return nil
+	}
+	avg := t.Total / float64(t.Days)
+	return &avg
+}
+
+// @Summary Get the statistics of a habit
+// @Description Completion rates, totals and trends over a range of days, aggregated by the database. A day is done when its check-ins meet the target of the habit, which a day without check-ins does for an at_most target; for a habit to quit, when it has no slip. Rates divide the done days by the days the habit was due, with weekly and monthly quotas spread evenly over their period, and only count days from the creation of the habit up to today.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param from query string false "First day as YYYY-MM-DD (default: a year before to, or the creation of the habit if later but not after to)"
+// @Param to query string false "Last day as YYYY-MM-DD (default today)"
+// @Param granularity query string false "Length of the periods" Enums(day, week, month) default(week)
+// @Param tz query string false "IANA time zone of the days (default: user time zone)"
+// @Success 200 {object} HabitStats
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/stats [get]
+func (srv *Server) GetHabitStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	loc, err := requestLocation(c)
+	if err != nil {
+		c.Error(invalidParam("Invalid time zone"))
+		return
+	}
+	granularity := c.DefaultQuery("granularity", granularityWeek)
+	if _, ok := movingAverageWindow[granularity]; !ok {
+		c.Error(invalidParam("Invalid granularity, use day, week or month"))
+		return
+	}
+	h, err := srv.ownedHabits(c).Where(habit.ID(id)).Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(notFound("Habit not found"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	today := civilDay(time.Now(), loc)
+	created := civilDay(h.CreatedAt, loc)
+	to := today
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse(time.DateOnly, v); err != nil {
+			c.Error(invalidParam("Invalid to date"))
+			return
+		}
+	}
+	from := to.AddDate(-1, 0, 1)
+	if from.Before(created) {
+		from = created
+	}
+	if from.After(to) {
+		// A range ending before the creation of the habit
+		from = to
+	}
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse(time.DateOnly, v); err != nil {
+			c.Error(invalidParam("Invalid from date"))
+			return
+		}
+	}
+	if from.After(to) {
+		c.Error(invalidParam("Invalid range, from is after to"))
+		return
+	}
+	if daysBetween(from, to) >= maxStatsDays {
+		c.Error(invalidParam("Invalid range, use at most 5 years"))
+		return
+	}
+
+	sched, err := newSchedule(h, loc)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	tgt := newTarget(h)
+	quit := h.Polarity == habit.PolarityQuit
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+	periodRows, err := srv.aggregateDays(ctx, h.ID, tgt, loc, start, end, func(d, day string) string {
+		return periodExpr(d, day, granularity)
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	weekdayRows, err := srv.aggregateDays(ctx, h.ID, tgt, loc, start, end, weekdayExpr)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Count the due days of every period and weekday
+	periods := make(map[string]*statsTally)
+	var order []string
+	var weekdays [7]statsTally
+	due := sched.dueBetween(from, to)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := periodStart(day, granularity).Format(time.DateOnly)
+		if periods[key] == nil {
+			periods[key] = &statsTally{}
+			order = append(order, key)
+		}
+		if day.Before(created) || day.After(today) {
+			continue
+		}
+		var n float64
+		switch {
+		case quit:
+			n = 1
+		case sched.isQuota():
+			n = float64(sched.quota) / float64(daysBetween(sched.periodStart(day), sched.nextPeriod(sched.periodStart(day))))
+		case due(day):
+			n = 1
+		}
+		periods[key].due += n
+		weekdays[day.Weekday()].due += n
+	}
+	for _, r := range periodRows {
+		if p := periods[r.Bucket]; p != nil {
+			p.statsRow = r
+		}
+	}
+	for _, r := range weekdayRows {
+		if wd, err := strconv.Atoi(r.Bucket); err == nil && wd >= 0 && wd < 7 {
+			weekdays[wd].statsRow = r
+		}
+	}
+
+	stats := HabitStats{
+		From:        from.Format(time.DateOnly),
+		To:          to.Format(time.DateOnly),
+		Granularity: granularity,
+		Periods:     make([]PeriodStats, len(order)),
+	}
+	var total statsTally
+	window := movingAverageWindow[granularity]
+	for i, key := range order {
+		p := periods[key]
+		stats.Periods[i] = PeriodStats{
+			Start:        key,
+			Completions:  p.Checkins,
+			DoneDays:     p.done(quit, tgt.atMost),
+			Rate:         p.rate(quit, tgt.atMost),
+			AverageValue: p.averageValue(tgt.measured),
+		}
+		sum, n := 0.0, 0
+		for _, prev := range stats.Periods[max(0, i-window+1) : i+1] {
+			if prev.Rate != nil {
+				sum += *prev.Rate
+				n++
+			}
+		}
+		if n > 0 {
+			avg := sum / float64(n)
+			stats.Periods[i].MovingAverage = &avg
+		}
+		total.Days += p.Days
+		total.Checkins += p.Checkins
+		total.Total += p.Total
+		total.Done += p.Done
+		total.due += p.due
+	}
+	stats.TotalCompletions = total.Checkins
+	stats.DoneDays = total.done(quit, tgt.atMost)
+	stats.Rate = total.rate(quit, tgt.atMost)
+	stats.AverageValue = total.averageValue(tgt.measured)
+	for wd, t := range weekdays {
+		r := t.rate(quit, tgt.atMost)
+		if r == nil || total.Days == 0 {
+			continue
+		}
+		ws := &WeekdayStats{Weekday: wd, Rate: *r}
+		if stats.BestWeekday == nil || ws.Rate > stats.BestWeekday.Rate {
+			stats.BestWeekday = ws
+		}
+		if stats.WorstWeekday == nil || ws.Rate < stats.WorstWeekday.Rate {
+			stats.WorstWeekday = ws
+		}
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// aggregateDays totals the check-ins of a habit in [start, end) per day in
+// loc, then groups the days by the expression bucket returns for the day
+// column of the given dialect. Each row counts the days with check-ins, the
+// check-ins, the sum of their values and the days meeting tgt.
+func (srv *Server) aggregateDays(ctx context.Context, habitID int, tgt target, loc *time.Location, start, end time.Time, bucket func(d, day string) string) ([]statsRow, error) {
+	var rows []statsRow
+	err := srv.client.Completion.Query().
+		Modify(func(s *sql.Selector) {
+			builder := sql.Dialect(s.Dialect())
+			t := builder.Table(completion.Table)
+			completedAt := t.C(completion.FieldCompletedAt)
+			days := builder.
+				Select().
+				AppendSelectExprAs(localDate(s.Dialect(), completedAt, loc, start, end), "day").
+				AppendSelectExprAs(sql.Expr("count(*)"), "checkins").
+				AppendSelectExprAs(sql.Expr("coalesce(sum("+t.C(completion.FieldValue)+"), 0)"), "total").
+				From(t).
+				Where(sql.And(
+					sql.EQ(t.C(completion.FieldHabitID), habitID),
+					// In UTC, for SQLite compares the stored text
+					sql.GTE(completedAt, start.UTC()),
+					sql.LT(completedAt, end.UTC()),
+				)).
+				GroupBy("day").
+				As("d")
+			met := sql.ExprFunc(func(b *sql.Builder) {
+				if !tgt.measured {
+					b.WriteString("1")
+					return
+				}
+				b.WriteString("CASE WHEN ").WriteString(days.C("total"))
+				if tgt.atMost {
+					b.WriteString(" <= ")
+				} else {
+					b.WriteString(" >= ")
+				}
+				b.Arg(tgt.value).WriteString(" THEN 1 ELSE 0 END")
+			})
+			s.From(days).
+				Select().
+				AppendSelectExprAs(sql.Expr(bucket(s.Dialect(), days.C("day"))), "bucket").
+				AppendSelectExprAs(sql.Expr("count(*)"), "days").
+				AppendSelectExprAs(sql.Expr("sum("+days.C("checkins")+")"), "checkins").
+				AppendSelectExprAs(sql.Expr("sum("+days.C("total")+")"), "total").
+				AppendSelectExprAs(sql.ExprFunc(func(b *sql.Builder) {
+					b.WriteString("sum(").Join(met).WriteString(")")
+				}), "done").
+				GroupBy("bucket").
+				OrderBy("bucket")
+		}).
+		Scan(ctx, &rows)
+	return rows, err
+}
+
+// localDate is the calendar day in loc of the time in column col, for times
+// in [start, end). Postgres converts with the time zone database and
+// returns a date. SQLite has none, so the UTC offsets loc has in the range
+// are applied with a CASE, and the day is a YYYY-MM-DD string.
+func localDate(d, col string, loc *time.Location, start, end time.Time) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		if d == dialect.Postgres {
+			b.WriteString("(").WriteString(col).WriteString(" AT TIME ZONE ").Arg(loc.String()).WriteString(")::date")
+			return
+		}
+		// Each offset applies until the next transition
+		type zone struct {
+			offset string
+			until  time.Time
+		}
+		var zones []zone
+		for t := start; ; {
+			_, offset := t.Zone()
+			modifier := strconv.Itoa(offset) + " seconds"
+			if offset >= 0 {
+				modifier = "+" + modifier
+			}
+			_, next := t.ZoneBounds()
+			if next.IsZero() || !next.Before(end) {
+				zones = append(zones, zone{offset: modifier})
+				break
+			}
+			zones = append(zones, zone{offset: modifier, until: next.UTC()})
+			t = next
+		}
+		b.WriteString("date(").WriteString(col).WriteString(", ")
+		if len(zones) == 1 {
+			b.Arg(zones[0].offset).WriteString(")")
+			return
+		}
+		b.WriteString("CASE")
+		for _, z := range zones[:len(zones)-1] {
+			b.WriteString(" WHEN ").WriteString(col).WriteString(" < ").Arg(z.until).WriteString(" THEN ").Arg(z.offset)
+		}
+		b.WriteString(" ELSE ").Arg(zones[len(zones)-1].offset).WriteString(" END)")
+	})
+}
+
+// periodExpr truncates the day column day to the first day of its period,
+// as YYYY-MM-DD.
+func periodExpr(d, day, granularity string) string {
+	if d == dialect.Postgres {
+		if granularity == granularityDay {
+			return "to_char(" + day + ", 'YYYY-MM-DD')"
+		}
+		return "to_char(date_trunc('" + granularity + "', " + day + "), 'YYYY-MM-DD')"
+	}
+	switch granularity {
+	case granularityWeek:
+		return "date(" + day + ", '-' || ((strftime('%w', " + day + ") + 6) % 7) || ' days')"
+	case granularityMonth:
+		return "strftime('%Y-%m-01', " + day + ")"
+	}
+	return day
+}
+
+// weekdayExpr is the day of the week of the day column day, 0 for Sunday.
+func weekdayExpr(d, day string) string {
+	if d == dialect.Postgres {
+		return "extract(dow from " + day + ")::int"
+	}
+	return "strftime('%w', " + day + ")"
+}
+
+// periodStart returns the first day of the period of the given granularity
+// containing day, like periodExpr does in SQL.
+func periodStart(day time.Time, granularity string) time.Time {
+	switch granularity {
+	case granularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case granularityMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
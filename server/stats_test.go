@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"api/server"
+)
+
+func TestHabitStats(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	today := time.Now().UTC()
+	yesterday := time.Date(today.Year(), today.Month(), today.Day()-1, 12, 0, 0, 0, time.UTC)
+	// Checked in once today, twice yesterday before the habit was created
+	ts.checkIn(h.ID, map[string]any{"completed_at": today.Format(time.RFC3339)})
+	ts.checkIn(h.ID, map[string]any{"completed_at": yesterday.Format(time.RFC3339)})
+	ts.checkIn(h.ID, map[string]any{"completed_at": yesterday.Add(-time.Minute).Format(time.RFC3339)})
+
+	var stats server.HabitStats
+	from := yesterday.Format(time.DateOnly)
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats?from=%s&granularity=day&tz=UTC", h.ID, from), nil, http.StatusOK, &stats)
+	if stats.TotalCompletions != 3 || stats.DoneDays != 2 {
+		t.Errorf("got %d completions on %d days, want 3 on 2", stats.TotalCompletions, stats.DoneDays)
+	}
+	// Only today counts towards the rate, the habit did not exist before
+	if stats.Rate == nil || *stats.Rate != 1 {
+		t.Errorf("got rate %v, want 1", stats.Rate)
+	}
+	if len(stats.Periods) != 2 || stats.Periods[0].Rate != nil {
+		t.Errorf("got periods %+v, want yesterday without a rate and today", stats.Periods)
+	}
+	if stats.BestWeekday == nil || stats.BestWeekday.Weekday != int(today.Weekday()) {
+		t.Errorf("got best weekday %+v, want %d", stats.BestWeekday, today.Weekday())
+	}
+}
+
+func TestHabitStatsInTimeZone(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	now := time.Now().In(berlin)
+	day := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, berlin)
+	// Half past midnight in Berlin, still the day before in UTC
+	ts.checkIn(h.ID, map[string]any{"completed_at": day.Add(30 * time.Minute).UTC().Format(time.RFC3339)})
+	ts.checkIn(h.ID, map[string]any{"completed_at": day.Add(-30 * time.Minute).UTC().Format(time.RFC3339)})
+
+	var stats server.HabitStats
+	date := day.Format(time.DateOnly)
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats?from=%s&to=%s&granularity=day&tz=Europe/Berlin", h.ID, date, date), nil, http.StatusOK, &stats)
+	if stats.TotalCompletions != 1 || len(stats.Periods) != 1 || stats.Periods[0].Start != date || stats.Periods[0].Completions != 1 {
+		t.Errorf("got %d completions in periods %+v, want 1 on %s", stats.TotalCompletions, stats.Periods, date)
+	}
+}
+
+func TestHabitStatsBeforeCreation(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+
+	// The default range ends at to, before the habit existed
+	var stats server.HabitStats
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats?to=2020-01-01", h.ID), nil, http.StatusOK, &stats)
+	if stats.From != "2020-01-01" || stats.To != "2020-01-01" {
+		t.Errorf("got range %s to %s, want 2020-01-01", stats.From, stats.To)
+	}
+	if stats.Rate != nil || stats.BestWeekday != nil || stats.WorstWeekday != nil {
+		t.Errorf("got rate %v and weekdays %v, %v for a range without check-ins, want none", stats.Rate, stats.BestWeekday, stats.WorstWeekday)
+	}
+
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats", h.ID), nil, http.StatusOK, &stats)
+	if stats.BestWeekday != nil || stats.WorstWeekday != nil {
+		t.Errorf("got weekdays %v, %v without check-ins, want none", stats.BestWeekday, stats.WorstWeekday)
+	}
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats?from=2020-01-02&to=2020-01-01", h.ID), nil, http.StatusBadRequest, nil)
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/stats?granularity=year", h.ID), nil, http.StatusBadRequest, nil)
+}
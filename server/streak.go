@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"api/ent"
+	"api/ent/completion"
+	"api/ent/habit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Streak summarises a habit's check-in history.
+type Streak struct {
+	CurrentStreak int        `json:"current_streak"`
+	LongestStreak int        `json:"longest_streak"`
+	LastCompleted *time.Time `json:"last_completed,omitempty"`
+}
+
+// History summarises the check-ins of a habit: a streak for a habit to
+// build, its slips for a habit to quit.
+type History struct {
+	*Streak
+	*Relapse
+}
+
+// current is what habits are sorted by as their streak: the current streak,
+// or the days since the last slip.
+func (h History) current() int {
+	if h.Relapse != nil {
+		return h.DaysSinceLastSlip
+	}
+	return h.CurrentStreak
+}
+
+// HabitResponse is a habit as returned by the read endpoints, including its
+// history.
+type HabitResponse struct {
+	*ent.Habit
+	History
+}
+
+// loadHistories computes the histories of all given habits with a single
+// query for their check-ins. Days are evaluated in loc.
+func (srv *Server) loadHistories(ctx context.Context, habits []*ent.Habit, loc *time.Location, now time.Time) (map[int]History, error) {
+	ids := make([]int, len(habits))
+	for i, h := range habits {
+		ids[i] = h.ID
+	}
+	checkins, err := srv.client.Completion.Query().
+		Where(completion.HabitIDIn(ids...)).
+		Select(completion.FieldHabitID, completion.FieldCompletedAt, completion.FieldValue).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byHabit := make(map[int][]*ent.Completion, len(habits))
+	for _, cp := range checkins {
+		byHabit[cp.HabitID] = append(byHabit[cp.HabitID], cp)
+	}
+	histories := make(map[int]History, len(habits))
+	for _, h := range habits {
+		if h.Polarity == habit.PolarityQuit {
+			r := computeRelapse(byHabit[h.ID], h.CreatedAt, loc, now)
+			histories[h.ID] = History{Relapse: &r}
+			continue
+		}
+		sched, err := newSchedule(h, loc)
+		if err != nil {
+			return nil, fmt.Errorf("habit %d: %w", h.ID, err)
+		}
+		s := computeStreak(byHabit[h.ID], h.CreatedAt, sched, newTarget(h), loc, now)
+		histories[h.ID] = History{Streak: &s}
+	}
+	return histories, nil
+}
+
+// computeStreak walks the calendar from the creation of the habit, or an
+// earlier check-in, up to today. Every scheduled day whose check-ins meet
+// the target extends the streak and every other scheduled day resets it,
+// except today, which may still be checked in. Days the habit is not
+// scheduled neither extend nor break it. For quota schedules the streak
+// counts consecutive periods (weeks or months) whose check-ins meet the
+// quota. LastCompleted is the latest check-in on a day the target was met.
+func computeStreak(checkins []*ent.Completion, created time.Time, sched *schedule, tgt target, loc *time.Location, now time.Time) Streak {
+	var s Streak
+	totals := tgt.totals(checkins, loc)
+	first := civilDay(created, loc)
+	for _, cp := range checkins {
+		day := civilDay(cp.CompletedAt, loc)
+		if day.Before(first) {
+			first = day
+		}
+		if tgt.metOn(totals, day) && (s.LastCompleted == nil || cp.CompletedAt.After(*s.LastCompleted)) {
+			last := cp.CompletedAt
+			s.LastCompleted = &last
+		}
+	}
+	today := civilDay(now, loc)
+	run := 0
+	step := func(met, current bool) {
+		switch {
+		case met:
+			run++
+			s.LongestStreak = max(s.LongestStreak, run)
+		case !current:
+			run = 0
+		}
+	}
+	if sched.isQuota() {
+		current := sched.periodStart(today)
+		for start := sched.periodStart(first); !start.After(current); start = sched.nextPeriod(start) {
+			step(tgt.periodMet(totals, sched.quota, start, sched.nextPeriod(start)), start.Equal(current))
+		}
+	} else {
+		due := sched.dueBetween(first, today)
+		for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+			if due(day) {
+				step(tgt.metOn(totals, day), day.Equal(today))
+			}
+		}
+	}
+	s.CurrentStreak = run
+	return s
+}
+
+// civilDay returns the calendar day of t in loc, normalised to midnight UTC so
+// that it can be used as a map key and stepped with AddDate.
+func civilDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// requestLocation returns the time zone requested through the tz query
+// parameter, defaulting to the time zone of the authenticated user.
+func requestLocation(c *gin.Context) (*time.Location, error) {
+	if tz := c.Query("tz"); tz != "" {
+		return time.LoadLocation(tz)
+	}
+	return time.LoadLocation(currentUser(c).Timezone)
+}
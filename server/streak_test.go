@@ -0,0 +1,195 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"api/ent"
+	"api/ent/habit"
+)
+
+// checkinsAt returns check-ins at the given RFC 3339 times.
+func checkinsAt(t *testing.T, times ...string) []*ent.Completion {
+	t.Helper()
+	checkins := make([]*ent.Completion, len(times))
+	for i, s := range times {
+		at, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkins[i] = &ent.Completion{CompletedAt: at}
+	}
+	return checkins
+}
+
+// withValues sets the values of check-ins, in order.
+func withValues(checkins []*ent.Completion, values ...float64) []*ent.Completion {
+	for i := range values {
+		checkins[i].Value = &values[i]
+	}
+	return checkins
+}
+
+// streakNow is a Wednesday evening, the habits were created the Sunday of
+// the previous week.
+var (
+	streakNow     = time.Date(2026, 3, 11, 20, 0, 0, 0, time.UTC)
+	streakCreated = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+)
+
+func TestComputeStreak(t *testing.T) {
+	tests := []struct {
+		name             string
+		checkins         []string
+		current, longest int
+	}{
+		{"no check-ins", nil, 0, 0},
+		{"checked in today", []string{"2026-03-09T08:00:00Z", "2026-03-10T08:00:00Z", "2026-03-11T08:00:00Z"}, 3, 3},
+		{"today still open", []string{"2026-03-09T08:00:00Z", "2026-03-10T08:00:00Z"}, 2, 2},
+		{"twice a day", []string{"2026-03-10T08:00:00Z", "2026-03-10T18:00:00Z"}, 1, 1},
+		{"broken", []string{"2026-03-02T08:00:00Z", "2026-03-03T08:00:00Z", "2026-03-04T08:00:00Z", "2026-03-10T08:00:00Z"}, 1, 3},
+		{"missed yesterday", []string{"2026-03-08T08:00:00Z", "2026-03-09T08:00:00Z"}, 0, 2},
+		{"before the creation", []string{"2026-02-27T08:00:00Z", "2026-02-28T08:00:00Z"}, 0, 2},
+	}
+	sched := &schedule{kind: habit.ScheduleDaily}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := computeStreak(checkinsAt(t, tt.checkins...), streakCreated, sched, target{value: 1}, time.UTC, streakNow)
+			if s.CurrentStreak != tt.current || s.LongestStreak != tt.longest {
+				t.Errorf("got streak %d, longest %d; want %d, %d", s.CurrentStreak, s.LongestStreak, tt.current, tt.longest)
+			}
+			if (s.LastCompleted != nil) != (len(tt.checkins) > 0) {
+				t.Errorf("got last completion %v for %d check-ins", s.LastCompleted, len(tt.checkins))
+			}
+		})
+	}
+}
+
+func TestComputeStreakTimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	// The second check-in is on March 11 in Berlin
+	checkins := checkinsAt(t, "2026-03-10T12:00:00Z", "2026-03-10T23:30:00Z")
+	sched := &schedule{kind: habit.ScheduleDaily}
+	if s := computeStreak(checkins, streakCreated, sched, target{value: 1}, berlin, streakNow); s.CurrentStreak != 2 {
+		t.Errorf("got streak %d in Berlin, want 2", s.CurrentStreak)
+	}
+	if s := computeStreak(checkins, streakCreated, sched, target{value: 1}, time.UTC, streakNow); s.CurrentStreak != 1 {
+		t.Errorf("got streak %d in UTC, want 1", s.CurrentStreak)
+	}
+}
+
+func TestComputeStreakSchedules(t *testing.T) {
+	tests := []struct {
+		name             string
+		habit            ent.Habit
+		checkins         []string
+		current, longest int
+	}{
+		{
+			"weekdays",
+			ent.Habit{Schedule: habit.ScheduleWeekdays, Weekdays: []int{1, 3}},
+			[]string{"2026-03-02T08:00:00Z", "2026-03-04T08:00:00Z", "2026-03-09T08:00:00Z"},
+			3, 3,
+		},
+		{
+			"interval",
+			ent.Habit{Schedule: habit.ScheduleInterval, IntervalDays: 2},
+			[]string{"2026-03-03T08:00:00Z", "2026-03-07T08:00:00Z", "2026-03-09T08:00:00Z"},
+			2, 2,
+		},
+		{
+			"rrule",
+			ent.Habit{Schedule: habit.ScheduleRrule, Rrule: "FREQ=WEEKLY;BYDAY=TU"},
+			[]string{"2026-03-03T08:00:00Z", "2026-03-10T08:00:00Z"},
+			2, 2,
+		},
+		{
+			// The current week still counts, although it is not met yet
+			"weekly quota",
+			ent.Habit{Schedule: habit.ScheduleWeekly, TimesPerPeriod: 2},
+			[]string{"2026-03-02T08:00:00Z", "2026-03-05T08:00:00Z", "2026-03-09T08:00:00Z"},
+			1, 1,
+		},
+		{
+			"monthly quota",
+			ent.Habit{Schedule: habit.ScheduleMonthly, TimesPerPeriod: 1},
+			[]string{"2026-02-10T08:00:00Z", "2026-03-05T08:00:00Z"},
+			2, 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.habit.CreatedAt = streakCreated
+			sched, err := newSchedule(&tt.habit, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := computeStreak(checkinsAt(t, tt.checkins...), streakCreated, sched, target{value: 1}, time.UTC, streakNow)
+			if s.CurrentStreak != tt.current || s.LongestStreak != tt.longest {
+				t.Errorf("got streak %d, longest %d; want %d, %d", s.CurrentStreak, s.LongestStreak, tt.current, tt.longest)
+			}
+		})
+	}
+}
+
+func TestComputeStreakTargets(t *testing.T) {
+	daily := &schedule{kind: habit.ScheduleDaily}
+	weekly := &schedule{kind: habit.ScheduleWeekly, quota: 2}
+	tests := []struct {
+		name             string
+		sched            *schedule
+		target           target
+		checkins         []*ent.Completion
+		current, longest int
+	}{
+		{
+			"at least",
+			daily,
+			target{measured: true, value: 8},
+			withValues(checkinsAt(t, "2026-03-09T08:00:00Z", "2026-03-10T08:00:00Z", "2026-03-10T18:00:00Z"), 4, 5, 3),
+			1, 1,
+		},
+		{
+			// Days without check-ins total 0, under the target
+			"at most",
+			daily,
+			target{measured: true, value: 2, atMost: true},
+			withValues(checkinsAt(t, "2026-03-05T08:00:00Z", "2026-03-08T08:00:00Z"), 3, 2),
+			6, 6,
+		},
+		{
+			"at most without check-ins",
+			daily,
+			target{measured: true, value: 2, atMost: true},
+			nil,
+			11, 11,
+		},
+		{
+			// The values add up over the week, and the second week has a
+			// single check-in
+			"weekly total",
+			weekly,
+			target{measured: true, value: 10},
+			withValues(checkinsAt(t, "2026-03-02T08:00:00Z", "2026-03-04T08:00:00Z", "2026-03-09T08:00:00Z"), 4, 6, 12),
+			1, 1,
+		},
+		{
+			"weekly total not met",
+			weekly,
+			target{measured: true, value: 10},
+			withValues(checkinsAt(t, "2026-03-02T08:00:00Z", "2026-03-04T08:00:00Z", "2026-03-09T08:00:00Z"), 4, 5, 12),
+			0, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := computeStreak(tt.checkins, streakCreated, tt.sched, tt.target, time.UTC, streakNow)
+			if s.CurrentStreak != tt.current || s.LongestStreak != tt.longest {
+				t.Errorf("got streak %d, longest %d; want %d, %d", s.CurrentStreak, s.LongestStreak, tt.current, tt.longest)
+			}
+		})
+	}
+}
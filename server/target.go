@@ -0,0 +1,150 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"api/ent"
+	"api/ent/habit"
+)
+
+// target is the goal the check-ins of a habit must meet on a day, or over a
+// period for quota schedules. Check-ins of boolean habits count as 1 towards
+// a target of at least 1, so a single one does.
+type target struct {
+	measured bool
+	value    float64
+	atMost   bool
+}
+
+// newTarget returns the target of h.
+func newTarget(h *ent.Habit) target {
+	if h.Type == habit.TypeBoolean || h.Target == nil {
+		return target{value: 1}
+	}
+	return target{
+		measured: true,
+		value:    *h.Target,
+		atMost:   h.TargetDirection == habit.TargetDirectionAtMost,
+	}
+}
+
+// met reports whether the total of a day meets the target.
+func (t target) met(total float64) bool {
+	if t.atMost {
+		return total <= t.value
+	}
+	return total >= t.value
+}
+
+// totals adds up the check-ins per calendar day in loc. Days without
+// check-ins are absent.
+func (t target) totals(checkins []*ent.Completion, loc *time.Location) map[time.Time]float64 {
+	totals := make(map[time.Time]float64)
+	for _, cp := range checkins {
+		v := 1.0
+		if t.measured {
+			v = 0
+			if cp.Value != nil {
+				v = *cp.Value
+			}
+		}
+		totals[civilDay(cp.CompletedAt, loc)] += v
+	}
+	return totals
+}
+
+// metOn reports whether the check-ins of day meet the target. A day
+// without check-ins totals 0, which meets an at most target.
+func (t target) metOn(totals map[time.Time]float64, day time.Time) bool {
+	return t.met(totals[day])
+}
+
+// periodTotal returns the number of days with check-ins in [start, end) and
+// the sum of their totals.
+func periodTotal(totals map[time.Time]float64, start, end time.Time) (days int, total float64) {
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if v, ok := totals[day]; ok {
+			days++
+			total += v
+		}
+	}
+	return days, total
+}
+
+// periodMet reports whether the check-ins in [start, end) meet a quota: the
+// habit was checked in on at least quota days, and the values add up to the
+// target over the whole period.
+func (t target) periodMet(totals map[time.Time]float64, quota int, start, end time.Time) bool {
+	days, total := periodTotal(totals, start, end)
+	return days >= quota && t.met(total)
+}
+
+// normalizeTarget validates the measurement fields of a habit received from
+// a client, defaults the type to boolean and the direction to at least, and
+// drops the fields that do not belong to the type. It returns the invalid
+// fields.
+func normalizeTarget(in *HabitInput) []FieldError {
+	if in.Type == "" {
+		in.Type = habit.TypeBoolean
+	}
+	if err := habit.TypeValidator(in.Type); err != nil {
+		return []FieldError{{Field: "type", Message: fmt.Sprintf("has unknown value %q", in.Type)}}
+	}
+	if in.Type == habit.TypeBoolean {
+		in.Unit, in.Target, in.TargetDirection = "", nil, ""
+		return nil
+	}
+	if in.Type == habit.TypeDuration {
+		// Durations are always in minutes
+		in.Unit = ""
+	}
+	var fields []FieldError
+	if in.TargetDirection == "" {
+		in.TargetDirection = habit.TargetDirectionAtLeast
+	}
+	if err := habit.TargetDirectionValidator(in.TargetDirection); err != nil {
+		fields = append(fields, FieldError{Field: "target_direction", Message: fmt.Sprintf("has unknown value %q, expected at_least or at_most", in.TargetDirection)})
+	}
+	switch {
+	case in.Target == nil:
+		fields = append(fields, FieldError{Field: "target", Message: fmt.Sprintf("is required for %s habits", in.Type)})
+	case *in.Target < 0:
+		fields = append(fields, FieldError{Field: "target", Message: "must not be negative"})
+	case *in.Target == 0 && in.TargetDirection != habit.TargetDirectionAtMost:
+		fields = append(fields, FieldError{Field: "target", Message: "must be positive for an at_least target"})
+	}
+	return fields
+}
+
+// setTarget copies the normalized measurement of in onto a create or update
+// mutation, clearing the fields that are not used.
+func setTarget(m *ent.HabitMutation, in *HabitInput) {
+	m.SetType(in.Type)
+	if in.Unit != "" {
+		m.SetUnit(in.Unit)
+	} else {
+		m.ClearUnit()
+	}
+	if in.Target != nil {
+		m.SetTarget(*in.Target)
+	} else {
+		m.ClearTarget()
+	}
+	if in.TargetDirection != "" {
+		m.SetTargetDirection(in.TargetDirection)
+	} else {
+		m.ClearTargetDirection()
+	}
+}
+
+// sameTarget reports whether in has the measurement of h.
+func sameTarget(in *HabitInput, h *ent.Habit) bool {
+	if in.Type != h.Type || in.Unit != h.Unit || in.TargetDirection != h.TargetDirection {
+		return false
+	}
+	if in.Target == nil || h.Target == nil {
+		return in.Target == h.Target
+	}
+	return *in.Target == *h.Target
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTargetTotals(t *testing.T) {
+	checkins := withValues(checkinsAt(t, "2026-03-10T08:00:00Z", "2026-03-10T18:00:00Z", "2026-03-11T08:00:00Z"), 2, 3)
+	boolean := target{value: 1}.totals(checkins, time.UTC)
+	if boolean[day(t, "2026-03-10")] != 2 || boolean[day(t, "2026-03-11")] != 1 {
+		t.Errorf("got boolean totals %v, want a check-in counting as 1", boolean)
+	}
+	measured := target{measured: true, value: 5}.totals(checkins, time.UTC)
+	if measured[day(t, "2026-03-10")] != 5 || measured[day(t, "2026-03-11")] != 0 {
+		t.Errorf("got measured totals %v, want the values added up", measured)
+	}
+	if _, ok := measured[day(t, "2026-03-12")]; ok {
+		t.Error("got a total for a day without check-ins")
+	}
+}
+
+func TestTargetMetOn(t *testing.T) {
+	totals := map[time.Time]float64{day(t, "2026-03-10"): 2, day(t, "2026-03-11"): 8}
+	tests := []struct {
+		name   string
+		target target
+		met    []string
+	}{
+		{"boolean", target{value: 1}, []string{"2026-03-10", "2026-03-11"}},
+		{"at least", target{measured: true, value: 8}, []string{"2026-03-11"}},
+		{"at most", target{measured: true, value: 2, atMost: true}, []string{"2026-03-09", "2026-03-10"}},
+		{"at most zero", target{measured: true, atMost: true}, []string{"2026-03-09"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, d := range []string{"2026-03-09", "2026-03-10", "2026-03-11"} {
+				want := false
+				for _, m := range tt.met {
+					want = want || m == d
+				}
+				if got := tt.target.metOn(totals, day(t, d)); got != want {
+					t.Errorf("got met %t on %s, want %t", got, d, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTargetPeriodMet(t *testing.T) {
+	totals := map[time.Time]float64{
+		day(t, "2026-03-09"): 4,
+		day(t, "2026-03-11"): 6,
+		day(t, "2026-03-16"): 1,
+	}
+	start, end := day(t, "2026-03-09"), day(t, "2026-03-16")
+	tests := []struct {
+		name   string
+		target target
+		quota  int
+		met    bool
+	}{
+		{"enough days", target{value: 1}, 2, true},
+		{"too few days", target{value: 1}, 3, false},
+		{"total reached", target{measured: true, value: 10}, 2, true},
+		{"total not reached", target{measured: true, value: 11}, 2, false},
+		{"total under", target{measured: true, value: 10, atMost: true}, 1, true},
+		{"total over", target{measured: true, value: 9, atMost: true}, 1, false},
+	}
+	for _, tt := range tests {
+		if got := tt.target.periodMet(totals, tt.quota, start, end); got != tt.met {
+			t.Errorf("%s: got met %t, want %t", tt.name, got, tt.met)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"api/ent"
+	"api/ent/apitoken"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiTokenPrefix marks personal API tokens, telling them apart from JWT
+// access tokens in the Authorization header.
+const apiTokenPrefix = "hab_"
+
+// Scopes an API token can be granted.
+const (
+	scopeHabitsRead    = "habits:read"
+	scopeHabitsWrite   = "habits:write"
+	scopeCheckinsRead  = "checkins:read"
+	scopeCheckinsWrite = "checkins:write"
+)
+
+var allScopes = []string{scopeHabitsRead, scopeHabitsWrite, scopeCheckinsRead, scopeCheckinsWrite}
+
+// TokenInput is the request body for creating an API token.
+type TokenInput struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is optional; tokens without it never expire.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedToken is an API token together with its secret, which is only
+// returned once on creation.
+type CreatedToken struct {
+	*ent.APIToken
+	Token string `json:"token"`
+}
+
+// @Summary Create a personal API token
+// @Description API tokens cannot be used to manage API tokens.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body TokenInput true "Name, scopes (habits:read, habits:write, checkins:read, checkins:write) and optional expiry"
+// @Success 200 {object} CreatedToken
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 422 {object} Problem
+// @Router /tokens [post]
+func (srv *Server) CreateToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input TokenInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		c.Error(invalidField("name", "cannot be empty"))
+		return
+	}
+	if len(input.Scopes) == 0 {
+		c.Error(invalidField("scopes", "needs at least one scope"))
+		return
+	}
+	for _, s := range input.Scopes {
+		if !slices.Contains(allScopes, s) {
+			c.Error(invalidField("scopes", "has unknown scope "+s))
+			return
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		c.Error(invalidField("expires_at", "must be in the future"))
+		return
+	}
+	secret, err := newToken()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	secret = apiTokenPrefix + secret
+	slices.Sort(input.Scopes)
+	t, err := srv.client.APIToken.Create().
+		SetUserID(currentUser(c).ID).
+		SetName(strings.TrimSpace(input.Name)).
+		SetPrefix(secret[:len(apiTokenPrefix)+6]).
+		SetTokenHash(hashToken(secret)).
+		SetScopes(slices.Compact(input.Scopes)).
+		SetNillableExpiresAt(input.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, CreatedToken{APIToken: t, Token: secret})
+}
+
+// @Summary List personal API tokens
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ent.APIToken
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Router /tokens [get]
+func (srv *Server) GetTokens(c *gin.Context) {
+	ctx := c.Request.Context()
+	tokens, err := srv.client.APIToken.Query().
+		Where(apitoken.UserID(currentUser(c).ID)).
+		Order(ent.Desc(apitoken.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// @Summary Revoke a personal API token
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Token ID"
+// @Success 200 {object} object
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Router /tokens/{id} [delete]
+func (srv *Server) DeleteToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid token ID"))
+		return
+	}
+	err = srv.client.APIToken.DeleteOneID(id).
+		Where(apitoken.UserID(currentUser(c).ID)).
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(notFound("Token not found"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
+
+// RequireScope rejects requests authenticated with an API token that was
+// not granted scope. Logins through JWT or cookie are not restricted.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, isToken := scopesFromContext(c.Request.Context())
+		if isToken && !slices.Contains(scopes, scope) {
+			abort(c, problem(http.StatusForbidden, codeInsufficientScope, "Token lacks scope "+scope))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RejectAPITokens restricts a route to interactive logins, so that a
+// leaked API token cannot be used to mint new ones.
+func RejectAPITokens(c *gin.Context) {
+	if _, isToken := scopesFromContext(c.Request.Context()); isToken {
+		abort(c, problem(http.StatusForbidden, codeForbidden, "API tokens cannot manage API tokens"))
+		return
+	}
+	c.Next()
+}
+
+// authenticateAPIToken resolves the user of a personal API token and
+// records its use.
+func (srv *Server) authenticateAPIToken(ctx context.Context, secret string) (*ent.APIToken, error) {
+	t, err := srv.client.APIToken.Query().
+		Where(
+			apitoken.TokenHash(hashToken(secret)),
+			apitoken.Or(apitoken.ExpiresAtIsNil(), apitoken.ExpiresAtGT(time.Now())),
+		).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.client.APIToken.UpdateOne(t).SetLastUsedAt(time.Now()).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// scopesCtxKey is the request context key of the scopes of an API token.
+type scopesCtxKey struct{}
+
+// withScopes returns a copy of ctx restricted to the given scopes.
+func withScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesCtxKey{}, scopes)
+}
+
+// scopesFromContext returns the scopes stored by withScopes, and whether the
+// request was authenticated with an API token at all.
+func scopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesCtxKey{}).([]string)
+	return scopes, ok
+}
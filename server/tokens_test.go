@@ -0,0 +1,42 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"api/ent"
+	"api/server"
+)
+
+func TestTokenScopes(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	var h ent.Habit
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "Read"}, http.StatusOK, &h)
+	var created server.CreatedToken
+	ts.call(http.MethodPost, "/tokens", server.TokenInput{Name: "dashboard", Scopes: []string{"habits:read"}}, http.StatusOK, &created)
+	if created.Token == "" {
+		t.Fatal("got no token secret")
+	}
+	ts.call(http.MethodPost, "/tokens", server.TokenInput{Name: "bad", Scopes: []string{"habits:admin"}}, http.StatusUnprocessableEntity, nil)
+	ts.call(http.MethodPost, "/tokens", server.TokenInput{Name: "none"}, http.StatusUnprocessableEntity, nil)
+
+	login := ts.token
+	ts.token = created.Token
+	ts.call(http.MethodGet, "/habits", nil, http.StatusOK, nil)
+	ts.call(http.MethodPost, "/habits", map[string]any{"name": "Write"}, http.StatusForbidden, nil)
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/checkins", h.ID), nil, http.StatusForbidden, nil)
+	// Tokens cannot manage tokens, even with every scope
+	ts.call(http.MethodGet, "/tokens", nil, http.StatusForbidden, nil)
+
+	ts.token = login
+	var tokens []ent.APIToken
+	ts.call(http.MethodGet, "/tokens", nil, http.StatusOK, &tokens)
+	if len(tokens) != 1 || tokens[0].ID != created.ID {
+		t.Fatalf("got tokens %+v, want the created one", tokens)
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("/tokens/%d", created.ID), nil, http.StatusOK, nil)
+	ts.token = created.Token
+	ts.call(http.MethodGet, "/habits", nil, http.StatusUnauthorized, nil)
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"api/ent"
+	"api/ent/completion"
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// purgeInterval is how often RunPurge empties the trash.
+const purgeInterval = time.Hour
+
+// TrashedHabit is a deleted habit waiting to be purged.
+type TrashedHabit struct {
+	*ent.Habit
+	// PurgeAt is when the habit is deleted for good, unset if the trash is
+	// kept forever.
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
+}
+
+// @Summary List deleted habits
+// @Description Deleted habits stay in the trash for the configured retention period and can be restored until then.
+// @Produce json
+// @Success 200 {array} TrashedHabit
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Security BearerAuth
+// @Router /habits/trash [get]
+func (srv *Server) GetTrash(c *gin.Context) {
+	ctx := schema.SkipSoftDelete(c.Request.Context())
+	habits, err := srv.ownedHabits(c).
+		Where(habit.DeletedAtNotNil()).
+		Order(ent.Desc(habit.FieldDeletedAt), ent.Desc(habit.FieldID)).
+		All(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resp := make([]TrashedHabit, len(habits))
+	for i, h := range habits {
+		resp[i] = TrashedHabit{Habit: h}
+		if retention := srv.trashRetention(); retention > 0 {
+			purgeAt := h.DeletedAt.Add(retention)
+			resp[i].PurgeAt = &purgeAt
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Restore a deleted habit
+// @Description Moves a habit out of the trash, together with its check-ins. Fails with 409 if another habit has taken its name in the meantime.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param If-Match header string false "Only restore if the habit still has this ETag"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "New version of the habit"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 412 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/restore [post]
+func (srv *Server) RestoreHabit(c *gin.Context) {
+	ctx := schema.SkipSoftDelete(c.Request.Context())
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	update := srv.client.Habit.UpdateOneID(id).
+		Where(habit.OwnerID(currentUser(c).ID), habit.DeletedAtNotNil()).
+		ClearDeletedAt()
+	if p := ifMatch(c); p != nil {
+		update.Where(p)
+	}
+	h, err := update.Save(ctx)
+	if ent.IsNotFound(err) {
+		exists, err := srv.ownedHabits(c).Where(habit.ID(id), habit.DeletedAtNotNil()).Exist(ctx)
+		switch {
+		case err != nil:
+			c.Error(err)
+		case exists:
+			c.Error(errHabitModified)
+		default:
+			c.Error(notFound("Habit not found in trash"))
+		}
+		return
+	}
+	if ent.IsConstraintError(err) {
+		// Another habit took the name while this one was in the trash
+		name, err := srv.ownedHabits(c).Where(habit.ID(id)).Select(habit.FieldName).String(ctx)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		srv.habitNameTaken(c.Request.Context(), c, name)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// @Summary Archive a habit
+// @Description Archived habits are hidden from the habit list and the due list but keep their check-ins, streaks and search results.
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param If-Match header string false "Only archive if the habit still has this ETag"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "New version of the habit"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/archive [post]
+func (srv *Server) ArchiveHabit(c *gin.Context) {
+	srv.setArchived(c, true)
+}
+
+// @Summary Unarchive a habit
+// @Produce json
+// @Param id path int true "Habit ID"
+// @Param If-Match header string false "Only unarchive if the habit still has this ETag"
+// @Success 200 {object} ent.Habit
+// @Header 200 {string} ETag "New version of the habit"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 412 {object} Problem
+// @Security BearerAuth
+// @Router /habits/{id}/unarchive [post]
+func (srv *Server) UnarchiveHabit(c *gin.Context) {
+	srv.setArchived(c, false)
+}
+
+func (srv *Server) setArchived(c *gin.Context, archived bool) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("id"))
+	if err != nil {
+		c.Error(invalidParam("Invalid habit ID"))
+		return
+	}
+	update := srv.client.Habit.UpdateOneID(id).
+		Where(habit.OwnerID(currentUser(c).ID)).
+		SetArchived(archived)
+	if p := ifMatch(c); p != nil {
+		update.Where(p)
+	}
+	h, err := update.Save(ctx)
+	if ent.IsNotFound(err) {
+		srv.habitWriteFailed(ctx, c, id)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// trashRetention is how long deleted habits are kept, 0 for forever.
+func (srv *Server) trashRetention() time.Duration {
+	return time.Duration(srv.cfg.TrashRetentionDays) * 24 * time.Hour
+}
+
+// PurgeTrash permanently deletes the habits, and their check-ins, that
+// have been in the trash for longer than the retention period. It returns
+// the number of habits deleted.
+func (srv *Server) PurgeTrash(ctx context.Context) (int, error) {
+	retention := srv.trashRetention()
+	if retention == 0 {
+		return 0, nil
+	}
+	ctx = schema.SkipSoftDelete(ctx)
+	expired := habit.DeletedAtLT(time.Now().Add(-retention))
+	var n int
+	err := withTx(ctx, srv.client, func(tx *ent.Tx) (err error) {
+		// The check-ins are deleted first so that they are audited, rather
+		// than removed by the foreign key
+		_, err = tx.Completion.Delete().
+			Where(completion.HasHabitWith(expired)).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		n, err = tx.Habit.Delete().Where(expired).Exec(ctx)
+		return err
+	})
+	return n, err
+}
+
+// RunPurge calls PurgeTrash and PurgeIdempotencyKeys every hour until ctx
+// is done.
+func (srv *Server) RunPurge(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		n, err := srv.PurgeTrash(ctx)
+		if err != nil {
+			slog.Error("purging trash", "err", err)
+		} else if n > 0 {
+			slog.Info("purged trash", "habits", n)
+		}
+		if n, err := srv.PurgeIdempotencyKeys(ctx); err != nil {
+			slog.Error("purging idempotency keys", "err", err)
+		} else if n > 0 {
+			slog.Info("purged idempotency keys", "keys", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// withTx runs fn in a transaction of client, which is committed if fn
+// returns nil and rolled back otherwise.
+func withTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,50 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"api/audit"
+	"api/config"
+	"api/ent/auditevent"
+	"api/ent/schema"
+)
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.TrashRetentionDays = 7 })
+	ctx := schema.SkipSoftDelete(context.Background())
+	old := ts.createHabit(map[string]any{"name": "Read"})
+	recent := ts.createHabit(map[string]any{"name": "Write"})
+	ts.checkIn(old.ID, nil)
+	ts.checkIn(old.ID, nil)
+	for _, id := range []int{old.ID, recent.ID} {
+		ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d", id), nil, http.StatusOK, nil)
+	}
+	// Trashed long ago
+	err := ts.client.Habit.UpdateOneID(old.ID).
+		SetDeletedAt(time.Now().AddDate(0, 0, -8)).
+		Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := ts.srv.PurgeTrash(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("got %d habits purged, error %v; want 1", n, err)
+	}
+	ids := ts.client.Habit.Query().IDsX(ctx)
+	if len(ids) != 1 || ids[0] != recent.ID {
+		t.Errorf("got habits %v left, want %d", ids, recent.ID)
+	}
+	// The check-ins leave a history too
+	deleted := ts.client.AuditEvent.Query().
+		Where(auditevent.EntityType(audit.EntityCheckin), auditevent.OperationEQ(auditevent.OperationDelete)).
+		CountX(ctx)
+	if deleted != 2 {
+		t.Errorf("got %d check-in deletions audited, want 2", deleted)
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+
+	"api/audit"
+	"api/ent"
+	"api/ent/auditevent"
+	"api/ent/habit"
+	"api/ent/schema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errAlreadyUndone is returned for an event that was undone already. The
+// unique index on undo_of settles concurrent undos of the same event.
+var errAlreadyUndone = problem(http.StatusConflict, codeConflict, "Change was already undone")
+
+// @Summary Undo a change
+// @Description Reverts a habit update or deletion, or a check-in deletion, recorded in the audit log. Only possible within the undo window, while the habit has not changed since, and once per event.
+// @Produce json
+// @Param eventId path int true "Audit event ID"
+// @Success 200 {object} object "The restored habit or check-in"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 410 {object} Problem
+// @Security BearerAuth
+// @Router /undo/{eventId} [post]
+func (srv *Server) Undo(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := toInt(c.Param("eventId"))
+	if err != nil {
+		c.Error(invalidParam("Invalid event ID"))
+		return
+	}
+	ev, err := srv.client.AuditEvent.Query().
+		Where(auditevent.ID(id), auditevent.OwnerID(currentUser(c).ID)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		c.Error(notFound("Event not found"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if time.Since(ev.CreatedAt) > time.Duration(srv.cfg.UndoWindowMinutes)*time.Minute {
+		c.Error(problem(http.StatusGone, codeGone, "Too late to undo this change"))
+		return
+	}
+	undone, err := srv.client.AuditEvent.Query().Where(auditevent.UndoOf(ev.ID)).Exist(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if undone {
+		c.Error(errAlreadyUndone)
+		return
+	}
+
+	ctx = audit.WithUndo(ctx, ev.ID)
+	switch {
+	case ev.EntityType == audit.EntityHabit && ev.Operation != auditevent.OperationCreate && ev.After != nil:
+		srv.undoHabitChange(ctx, c, ev)
+	case ev.EntityType == audit.EntityCheckin && ev.Operation == auditevent.OperationDelete:
+		srv.undoCheckinDeletion(ctx, c, ev)
+	default:
+		c.Error(problem(http.StatusBadRequest, codeInvalidParameter, "This change cannot be undone"))
+	}
+}
+
+// undoHabitChange sets the fields changed by an update or soft delete back
+// to their previous values, provided the habit is still at the version the
+// change left it at.
+func (srv *Server) undoHabitChange(ctx context.Context, c *gin.Context, ev *ent.AuditEvent) {
+	// Undoing a delete updates a habit in the trash
+	ctx = schema.SkipSoftDelete(ctx)
+	version, _ := ev.After[habit.FieldVersion].(float64)
+	update := srv.client.Habit.UpdateOneID(ev.EntityID).
+		Where(habit.OwnerID(currentUser(c).ID), habit.Version(int(version)))
+	if err := restoreFields(ctx, update.Mutation(), ev.Before); ent.IsNotFound(err) {
+		c.Error(problem(http.StatusConflict, codeConflict, "Habit no longer exists"))
+		return
+	} else if err != nil {
+		c.Error(err)
+		return
+	}
+	h, err := update.Save(ctx)
+	if errors.Is(err, audit.ErrUndone) {
+		// A concurrent undo of the same event won
+		c.Error(errAlreadyUndone)
+		return
+	}
+	if ent.IsNotFound(err) {
+		c.Error(problem(http.StatusConflict, codeConflict, "Habit was changed since, undo its later changes first"))
+		return
+	}
+	if ent.IsConstraintError(err) {
+		c.Error(problem(http.StatusConflict, codeConflict, "Another habit has the name this one would get back, rename it first"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", habitETag(h))
+	c.JSON(http.StatusOK, h)
+}
+
+// restoreFields sets the fields of a habit update to the values recorded in
+// an audit event. Null clears a field, or resets it to its zero value if it
+// cannot be cleared. The version and update time are left to the hooks.
+func restoreFields(ctx context.Context, m *ent.HabitMutation, fields map[string]any) error {
+	for name, v := range fields {
+		if name == habit.FieldVersion || name == habit.FieldUpdatedAt {
+			continue
+		}
+		// The current value tells the Go type of the field
+		old, err := m.OldField(ctx, name)
+		if err != nil {
+			return err
+		}
+		typ := reflect.TypeOf(old)
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if v == nil {
+			if m.ClearField(name) == nil {
+				continue
+			}
+			if err := m.SetField(name, reflect.Zero(typ).Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		value := reflect.New(typ)
+		if err := json.Unmarshal(b, value.Interface()); err != nil {
+			return err
+		}
+		if err := m.SetField(name, value.Elem().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undoCheckinDeletion recreates a deleted check-in. It gets a new ID.
+func (srv *Server) undoCheckinDeletion(ctx context.Context, c *gin.Context, ev *ent.AuditEvent) {
+	var prev ent.Completion
+	b, err := json.Marshal(ev.Before)
+	if err == nil {
+		err = json.Unmarshal(b, &prev)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	exists, err := srv.ownedHabits(c).Where(habit.ID(prev.HabitID)).Exist(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !exists {
+		c.Error(problem(http.StatusConflict, codeConflict, "Habit of the check-in no longer exists, restore it first"))
+		return
+	}
+	create := srv.client.Completion.Create().
+		SetHabitID(prev.HabitID).
+		SetCompletedAt(prev.CompletedAt).
+		SetNillableValue(prev.Value).
+		SetCreatedAt(prev.CreatedAt)
+	if prev.Note != "" {
+		create.SetNote(prev.Note)
+	}
+	completion, err := create.Save(ctx)
+	if errors.Is(err, audit.ErrUndone) {
+		c.Error(errAlreadyUndone)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, completion)
+}
+
+// lastEventID returns the last of the event IDs collected with
+// audit.WithEventIDs, which clients pass to POST /undo, or 0 if the change
+// recorded none.
+func lastEventID(events []int) int {
+	if len(events) == 0 {
+		return 0
+	}
+	return events[len(events)-1]
+}
@@ -0,0 +1,98 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"api/config"
+	"api/ent"
+)
+
+func TestUndoHabitDeletion(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d", h.ID), nil, http.StatusOK, &deleted)
+
+	var restored ent.Habit
+	ts.call(http.MethodPost, fmt.Sprintf("/undo/%d", deleted.EventID), nil, http.StatusOK, &restored)
+	if restored.ID != h.ID || restored.DeletedAt != nil {
+		t.Fatalf("got habit %+v, want habit %d out of the trash", restored, h.ID)
+	}
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d", h.ID), nil, http.StatusOK, nil)
+	ts.call(http.MethodPost, fmt.Sprintf("/undo/%d", deleted.EventID), nil, http.StatusConflict, nil)
+}
+
+func TestUndoCheckinDeletionOnce(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	cp := ts.checkIn(h.ID, map[string]any{"note": "chapter 1"})
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d/checkins/%d", h.ID, cp.ID), nil, http.StatusOK, &deleted)
+
+	// Concurrent undos of the same event recreate the check-in once
+	const undos = 5
+	statuses := make([]int, undos)
+	var wg sync.WaitGroup
+	for i := range undos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := ts.request(http.MethodPost, fmt.Sprintf("/undo/%d", deleted.EventID), nil)
+			resp.Body.Close()
+			statuses[i] = resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	ok := 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusOK:
+			ok++
+		case http.StatusConflict:
+		default:
+			t.Errorf("got status %d, want 200 or 409", status)
+		}
+	}
+	if ok != 1 {
+		t.Errorf("got %d successful undos, want 1", ok)
+	}
+	var checkins []ent.Completion
+	ts.call(http.MethodGet, fmt.Sprintf("/habits/%d/checkins", h.ID), nil, http.StatusOK, &checkins)
+	if len(checkins) != 1 || checkins[0].Note != "chapter 1" {
+		t.Errorf("got check-ins %+v, want the deleted one back once", checkins)
+	}
+}
+
+func TestUndoAfterLaterChange(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	path := fmt.Sprintf("/habits/%d", h.ID)
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, path, nil, http.StatusOK, &deleted)
+	ts.call(http.MethodPost, path+"/restore", nil, http.StatusOK, nil)
+	ts.call(http.MethodPost, fmt.Sprintf("/undo/%d", deleted.EventID), nil, http.StatusConflict, nil)
+}
+
+func TestUndoDisabled(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t, func(cfg *config.Config) { cfg.UndoWindowMinutes = 0 })
+	h := ts.createHabit(map[string]any{"name": "Read"})
+	var deleted struct {
+		EventID int `json:"event_id"`
+	}
+	ts.call(http.MethodDelete, fmt.Sprintf("/habits/%d", h.ID), nil, http.StatusOK, &deleted)
+	ts.call(http.MethodPost, fmt.Sprintf("/undo/%d", deleted.EventID), nil, http.StatusGone, nil)
+	ts.call(http.MethodPost, "/undo/999", nil, http.StatusNotFound, nil)
+}